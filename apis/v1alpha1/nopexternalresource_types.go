@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// A NopExternalResourceSpec declares a pretend external resource.
+type NopExternalResourceSpec struct {
+	// ExternalName of the pretend external resource. A NopResource whose
+	// crossplane.io/external-name annotation matches imports this pretend
+	// external resource rather than creating one.
+	// +kubebuilder:validation:MinLength=1
+	ExternalName string `json:"externalName"`
+
+	// Fields of the pretend external resource. A NopResource that imports the
+	// pretend external resource writes them to its status.atProvider.fields.
+	// +optional
+	Fields runtime.RawExtension `json:"fields,omitempty"`
+
+	// ConnectionDetails of the pretend external resource. A NopResource that
+	// imports the pretend external resource emits them, unless it emits a
+	// connection detail of the same name per spec.forProvider.connectionDetails.
+	// +optional
+	ConnectionDetails map[string]string `json:"connectionDetails,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A NopExternalResource declares a pretend external resource that exists
+// before any NopResource manages it. It's useful for testing importing
+// existing resources, for example using an Observe only management policy.
+// The pretend external resource is imported the first time a NopResource
+// observes it. Deleting a NopResource does not delete its NopExternalResource.
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".spec.externalName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,nop}
+type NopExternalResource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NopExternalResourceSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// NopExternalResourceList contains a list of NopExternalResource.
+type NopExternalResourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NopExternalResource `json:"items"`
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A ProviderConfigSpec defines the desired state of a ProviderConfig.
+type ProviderConfigSpec struct {
+	// Credentials required to authenticate to this provider. NopResources
+	// don't orchestrate an external system, so by default no credentials
+	// are required. Credentials are validated only if an expected value is
+	// configured.
+	// +optional
+	Credentials ProviderCredentials `json:"credentials,omitempty"`
+
+	// ObserveLatency is how long each observation of a pretend external
+	// resource should take. By default observations are instantaneous.
+	// +optional
+	ObserveLatency *metav1.Duration `json:"observeLatency,omitempty"`
+
+	// ErrorRatePercent is the chance, from 0 to 100, that any operation on a
+	// pretend external resource should return an error. By default operations
+	// never return an error.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ErrorRatePercent *int32 `json:"errorRatePercent,omitempty"`
+
+	// Outage causes all operations on pretend external resources to return an
+	// error, as if the fake cloud were unavailable.
+	// +optional
+	Outage bool `json:"outage,omitempty"`
+
+	// ConditionAfter is the default condition schedule of NopResources that
+	// use this ProviderConfig. It is used by NopResources that don't specify
+	// their own spec.forProvider.conditionAfter.
+	// +optional
+	ConditionAfter []ResourceConditionAfter `json:"conditionAfter,omitempty"`
+}
+
+// ProviderCredentials required to authenticate.
+type ProviderCredentials struct {
+	// Source of the provider credentials.
+	// +kubebuilder:validation:Enum=None;Secret;Environment;Filesystem
+	// +kubebuilder:default=None
+	// +optional
+	Source xpv1.CredentialsSource `json:"source,omitempty"`
+
+	xpv1.CommonCredentialSelectors `json:",inline"`
+
+	// ExpectedSecretRef selects a key of a Secret containing the credentials
+	// the fake cloud accepts. When it is set NopResources can't connect to
+	// the fake cloud unless the credentials read from Source match. Update
+	// this Secret to simulate credentials being revoked or rotated.
+	// +optional
+	ExpectedSecretRef *xpv1.SecretKeySelector `json:"expectedSecretRef,omitempty"`
+}
+
+// A ProviderConfigStatus reflects the observed state of a ProviderConfig.
+type ProviderConfigStatus struct {
+	xpv1.ProviderConfigStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ProviderConfig configures the Nop provider. NopResources that reference a
+// ProviderConfig that does not exist cannot be reconciled, and a
+// ProviderConfig cannot be deleted while NopResources use it. NopResources
+// inherit the faults, latency, and default conditions of their ProviderConfig.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,nop}
+type ProviderConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProviderConfigSpec   `json:"spec"`
+	Status ProviderConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProviderConfigList contains a list of ProviderConfig.
+type ProviderConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderConfig `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ProviderConfigUsage indicates that a resource is using a ProviderConfig.
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="CONFIG-NAME",type="string",JSONPath=".providerConfigRef.name"
+// +kubebuilder:printcolumn:name="RESOURCE-KIND",type="string",JSONPath=".resourceRef.kind"
+// +kubebuilder:printcolumn:name="RESOURCE-NAME",type="string",JSONPath=".resourceRef.name"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,nop}
+type ProviderConfigUsage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	xpv1.ProviderConfigUsage `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProviderConfigUsageList contains a list of ProviderConfigUsage.
+type ProviderConfigUsageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderConfigUsage `json:"items"`
+}
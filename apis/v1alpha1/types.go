@@ -24,12 +24,131 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
+// A ScheduleAnchor is an event that a scheduled time is relative to.
+// +kubebuilder:validation:Enum=Creation;LastSpecChange;Deletion
+type ScheduleAnchor string
+
+// Schedule anchors.
+const (
+	// ScheduleAnchorCreation is when the NopResource was created.
+	ScheduleAnchorCreation ScheduleAnchor = "Creation"
+
+	// ScheduleAnchorLastSpecChange is when a change to the NopResource's spec
+	// was last observed, or when it was created if its spec never changed.
+	// Changes the provider makes to the spec, like late-initializing fields
+	// or resolving dependsOnRefs, are not spec changes.
+	ScheduleAnchorLastSpecChange ScheduleAnchor = "LastSpecChange"
+
+	// ScheduleAnchorDeletion is when the NopResource was deleted.
+	ScheduleAnchorDeletion ScheduleAnchor = "Deletion"
+)
+
 // ResourceConditionAfter specifies a condition of a NopResource that should be
 // set after a certain duration.
 type ResourceConditionAfter struct {
-	// Time is the duration after which the condition should be set.
+	// Time is the duration after the anchor event at which the condition
+	// should be set.
 	Time metav1.Duration `json:"time"`
 
+	// Anchor is the event Time is relative to. A condition anchored to
+	// LastSpecChange is set again each time the NopResource's spec changes. A
+	// condition anchored to Deletion is only set once the NopResource has been
+	// deleted.
+	// +kubebuilder:default=Creation
+	// +optional
+	Anchor ScheduleAnchor `json:"anchor,omitempty"`
+
+	// Jitter is the maximum random duration that should be added to Time. The
+	// random duration is derived from the NopResource's UID, so it differs
+	// between NopResources but is always the same for a given NopResource.
+	// +optional
+	Jitter *metav1.Duration `json:"jitter,omitempty"`
+
+	// ProbabilityPercent is the chance, from 0 to 100, that the condition
+	// should be set at all. Like Jitter, whether the condition is set is
+	// derived from the NopResource's UID. By default the condition is always
+	// set.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ProbabilityPercent *int32 `json:"probabilityPercent,omitempty"`
+
+	// ConditionType to set - e.g. Ready.
+	ConditionType xpv1.ConditionType `json:"conditionType"`
+
+	// ConditionStatus to set - e.g. True.
+	ConditionStatus corev1.ConditionStatus `json:"conditionStatus"`
+
+	// ConditionReason to set - e.g. Available.
+	// +optional
+	ConditionReason *xpv1.ConditionReason `json:"conditionReason,omitempty"`
+
+	// ConditionMessage to set - e.g. "{{ .metadata.name }} is available". The
+	// message is a Go template that is executed against the NopResource. In
+	// addition to the functions supported by ExternalNameFormat it may use the
+	// age function, which returns how long ago the NopResource was created.
+	// If the message can't be rendered, for example because it refers to a
+	// field that isn't set, the condition is still set and its message is the
+	// error that prevented the message from being rendered.
+	// +optional
+	ConditionMessage *string `json:"conditionMessage,omitempty"`
+}
+
+// ResourceConditionCycle specifies conditions of a NopResource that should be
+// set repeatedly.
+type ResourceConditionCycle struct {
+	// Period after which the cycle repeats.
+	Period metav1.Duration `json:"period"`
+
+	// Anchor is the event the first cycle starts at. Subsequent cycles start
+	// each time the period passes.
+	// +kubebuilder:default=Creation
+	// +optional
+	Anchor ScheduleAnchor `json:"anchor,omitempty"`
+
+	// Conditions to set during each cycle. Each condition's time is relative
+	// to the start of the cycle, and its anchor is ignored. Conditions with a
+	// time greater than or equal to the period are never set. Conditions that
+	// jitter pushes past the end of the cycle wrap around to its start.
+	Conditions []ResourceConditionAfter `json:"conditions"`
+}
+
+// A FieldOperator compares the value of a field to a value.
+// +kubebuilder:validation:Enum=Equal;NotEqual;GreaterThan;GreaterThanOrEqual;LessThan;LessThanOrEqual;Exists;DoesNotExist
+type FieldOperator string
+
+// Field operators.
+const (
+	FieldOperatorEqual              FieldOperator = "Equal"
+	FieldOperatorNotEqual           FieldOperator = "NotEqual"
+	FieldOperatorGreaterThan        FieldOperator = "GreaterThan"
+	FieldOperatorGreaterThanOrEqual FieldOperator = "GreaterThanOrEqual"
+	FieldOperatorLessThan           FieldOperator = "LessThan"
+	FieldOperatorLessThanOrEqual    FieldOperator = "LessThanOrEqual"
+	FieldOperatorExists             FieldOperator = "Exists"
+	FieldOperatorDoesNotExist       FieldOperator = "DoesNotExist"
+)
+
+// ResourceConditionWhen specifies a condition of a NopResource that should be
+// set when one of its fields has a certain value.
+type ResourceConditionWhen struct {
+	// FieldPath of the NopResource field to compare - e.g.
+	// spec.forProvider.fields.replicas.
+	FieldPath string `json:"fieldPath"`
+
+	// Operator used to compare the field to Value. GreaterThan,
+	// GreaterThanOrEqual, LessThan, and LessThanOrEqual may only be used to
+	// compare numbers. Only DoesNotExist matches a field that does not exist.
+	// +kubebuilder:default=Equal
+	// +optional
+	Operator FieldOperator `json:"operator,omitempty"`
+
+	// Value to compare the field to. The value is parsed as JSON - e.g. 3,
+	// true, or "cool". Values that aren't valid JSON are compared as strings.
+	// Value is ignored by the Exists and DoesNotExist operators.
+	// +optional
+	Value *string `json:"value,omitempty"`
+
 	// ConditionType to set - e.g. Ready.
 	ConditionType xpv1.ConditionType `json:"conditionType"`
 
@@ -39,49 +158,425 @@ type ResourceConditionAfter struct {
 	// ConditionReason to set - e.g. Available.
 	// +optional
 	ConditionReason *xpv1.ConditionReason `json:"conditionReason,omitempty"`
+
+	// ConditionMessage to set. Like a ConditionAfter's message it is a Go
+	// template that is executed against the NopResource.
+	// +optional
+	ConditionMessage *string `json:"conditionMessage,omitempty"`
 }
 
+// An ExternalOperation is an operation on a NopResource's pretend external
+// resource.
+// +kubebuilder:validation:Enum=Observe;Create;Update;Delete
+type ExternalOperation string
+
+// External operations.
+const (
+	ExternalOperationObserve ExternalOperation = "Observe"
+	ExternalOperationCreate  ExternalOperation = "Create"
+	ExternalOperationUpdate  ExternalOperation = "Update"
+	ExternalOperationDelete  ExternalOperation = "Delete"
+)
+
+// ResourceErrorAfter specifies an error an operation on a NopResource's
+// pretend external resource should return after a certain duration.
+type ResourceErrorAfter struct {
+	// Time is the duration after which the error should be returned.
+	Time metav1.Duration `json:"time"`
+
+	// Duration for which the error should be returned. By default the error
+	// is returned indefinitely once Time has passed.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Count of calls that should return the error once Time has passed. By
+	// default every call returns the error.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Count *int64 `json:"count,omitempty"`
+
+	// Operation that should return the error - e.g. Create.
+	Operation ExternalOperation `json:"operation"`
+
+	// ErrorMessage to return - e.g. AccessDenied.
+	ErrorMessage string `json:"errorMessage"`
+}
+
+// A CreateMode determines how a NopResource's pretend external resource is
+// created.
+// +kubebuilder:validation:Enum=Asynchronous;Synchronous
+type CreateMode string
+
+// Create modes.
+const (
+	// CreateModeAsynchronous pretend external resources are created
+	// immediately, but aren't ready until their create duration has passed.
+	CreateModeAsynchronous CreateMode = "Asynchronous"
+
+	// CreateModeSynchronous pretend external resources aren't created until
+	// their create duration has passed. The create operation blocks until
+	// then.
+	CreateModeSynchronous CreateMode = "Synchronous"
+)
+
 // ResourceConnectionDetail specifies a connection detail a NopResource should
-// emit.
+// emit. At most one of Value, ValueBase64, and ValueFrom may be set. The
+// connection detail's value is empty if none are set.
+// +kubebuilder:validation:XValidation:rule="[has(self.value), has(self.valueBase64), has(self.valueFrom)].filter(x, x).size() <= 1",message="at most one of value, valueBase64, and valueFrom may be set"
 type ResourceConnectionDetail struct {
 	// Name of the connection detail.
 	Name string `json:"name"`
 
 	// Value of the connection detail.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// ValueBase64 is the base64 encoded value of the connection detail. The
+	// value is decoded and emitted byte for byte, so it may be binary.
+	// +optional
+	ValueBase64 []byte `json:"valueBase64,omitempty"`
+
+	// ValueFrom is the source of the connection detail's value.
+	// +optional
+	ValueFrom *ConnectionDetailSource `json:"valueFrom,omitempty"`
+}
+
+// ConnectionDetailSource is the source of a connection detail's value. Exactly
+// one of its fields must be set.
+// +kubebuilder:validation:XValidation:rule="[has(self.secretKeyRef), has(self.fieldPath), has(self.generated)].filter(x, x).size() == 1",message="exactly one of secretKeyRef, fieldPath, and generated must be set"
+type ConnectionDetailSource struct {
+	// SecretKeyRef selects a key of a Secret whose value should be emitted.
+	// +optional
+	SecretKeyRef *xpv1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// FieldPath of a NopResource field whose value should be emitted - e.g.
+	// status.atProvider.fields.endpoint. Fields that aren't strings are
+	// emitted as JSON. Nothing is emitted if the field does not exist.
+	// +optional
+	FieldPath *string `json:"fieldPath,omitempty"`
+
+	// Generated values are randomly generated when the connection detail is
+	// first observed. They're stored with the pretend external resource, so
+	// they don't change between reconciles. If the provider restarts they're
+	// recovered from the NopResource's writeConnectionSecretToRef. Values that
+	// are only published to an External Secret Store are not recovered; they
+	// are regenerated, and replacing them is recorded as a rotation.
+	// +optional
+	Generated *GeneratedConnectionDetail `json:"generated,omitempty"`
+}
+
+// A GeneratedType is a type of generated connection detail.
+// +kubebuilder:validation:Enum=Password;RSAKeyPair;TLSCertificate
+type GeneratedType string
+
+// Generated connection detail types.
+const (
+	// GeneratedTypePassword is a random password. It is emitted as the
+	// connection detail's name.
+	GeneratedTypePassword GeneratedType = "Password"
+
+	// GeneratedTypeRSAKeyPair is an RSA key pair. The PEM encoded private key
+	// is emitted as the connection detail's name, and the public key in SSH
+	// authorized_keys format as the name with a .pub suffix.
+	GeneratedTypeRSAKeyPair GeneratedType = "RSAKeyPair"
+
+	// GeneratedTypeTLSCertificate is a certificate signed by a self-signed CA.
+	// The PEM encoded certificate, private key, and CA certificate are emitted
+	// as the connection detail's name with .crt, .key, and .ca.crt suffixes.
+	GeneratedTypeTLSCertificate GeneratedType = "TLSCertificate"
+)
+
+// GeneratedConnectionDetail specifies how a connection detail's value should
+// be generated.
+type GeneratedConnectionDetail struct {
+	// Type of value to generate.
+	// +kubebuilder:default=Password
+	// +optional
+	Type GeneratedType `json:"type,omitempty"`
+
+	// Length of a generated password.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1024
+	// +kubebuilder:default=27
+	// +optional
+	Length *int32 `json:"length,omitempty"`
+
+	// CharacterSet of a generated password. By default passwords consist of
+	// lowercase letters, uppercase letters, and numbers.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	CharacterSet *string `json:"characterSet,omitempty"`
+
+	// Bits is the size of a generated RSA key. Keys are generated while the
+	// NopResource is observed, so large keys slow reconciliation.
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=4096
+	// +kubebuilder:default=2048
+	// +optional
+	Bits *int32 `json:"bits,omitempty"`
+
+	// CommonName of a generated TLS certificate. Like DNSNames it is a Go
+	// template that is executed against the NopResource. By default it is the
+	// NopResource's name.
+	// +optional
+	CommonName *string `json:"commonName,omitempty"`
+
+	// DNSNames are the subject alternative names of a generated TLS
+	// certificate. Each is a Go template that is executed against the
+	// NopResource - e.g. "{{ .spec.forProvider.fields.host }}".
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// RotationPeriod is how often the value should be regenerated. The
+	// NopResource's connection secret is updated each time the value is
+	// rotated. By default the value is never rotated.
+	// +optional
+	RotationPeriod *metav1.Duration `json:"rotationPeriod,omitempty"`
+}
+
+// ResourceFieldAfter specifies a value a NopResource should write to its
+// status.atProvider.fields after a certain duration.
+type ResourceFieldAfter struct {
+	// Time is the duration after the anchor event at which the value should
+	// be written.
+	Time metav1.Duration `json:"time"`
+
+	// Anchor is the event Time is relative to.
+	// +kubebuilder:default=Creation
+	// +optional
+	Anchor ScheduleAnchor `json:"anchor,omitempty"`
+
+	// FieldPath is the path within status.atProvider.fields the value should
+	// be written to - e.g. endpoint or network.addresses[0].
+	FieldPath string `json:"fieldPath"`
+
+	// Value to write. The value is parsed as JSON - e.g. 3, true, or
+	// "10.0.0.1". Values that aren't valid JSON are written as strings.
 	Value string `json:"value"`
 }
 
+// An ObservationFormat determines how a templated observation is written to
+// status.atProvider.fields.
+// +kubebuilder:validation:Enum=String;JSON
+type ObservationFormat string
+
+// Observation formats.
+const (
+	// ObservationFormatString observations are written as a string.
+	ObservationFormatString ObservationFormat = "String"
+
+	// ObservationFormatJSON observations are parsed as JSON, so they may be
+	// written as a number, boolean, array, or object.
+	ObservationFormatJSON ObservationFormat = "JSON"
+)
+
+// ResourceObservation specifies a value a NopResource should write to its
+// status.atProvider.fields each time it observes its pretend external
+// resource. Exactly one of FromFieldPath and Template should be set.
+type ResourceObservation struct {
+	// ToFieldPath is the path within status.atProvider.fields the value
+	// should be written to - e.g. endpoint or network.addresses[0].
+	ToFieldPath string `json:"toFieldPath"`
+
+	// FromFieldPath is the path of a NopResource field whose value should be
+	// copied - e.g. spec.forProvider.fields.replicas or metadata.uid. Nothing
+	// is written if the field does not exist.
+	// +optional
+	FromFieldPath *string `json:"fromFieldPath,omitempty"`
+
+	// Template is a Go template whose output should be written - e.g.
+	// "{{ .metadata.name }}.example.org". Like a ConditionAfter's message it
+	// is executed against the NopResource, and may use the age function.
+	// +optional
+	Template *string `json:"template,omitempty"`
+
+	// Format of the template's output.
+	// +kubebuilder:default=String
+	// +optional
+	Format ObservationFormat `json:"format,omitempty"`
+}
+
+// NopResourceInitParameters are the fields of a NopResource that are used only
+// when its pretend external resource is created.
+type NopResourceInitParameters struct {
+	// Fields the pretend external resource is created with, in addition to
+	// spec.forProvider.fields. Fields that are set here but not in
+	// spec.forProvider.fields are ignored after creation. They're never
+	// updated, and changes to them aren't considered drift.
+	// +optional
+	Fields runtime.RawExtension `json:"fields,omitempty"`
+}
+
 // NopResourceParameters are the configurable fields of a NopResource.
 type NopResourceParameters struct {
 	// ConditionAfter can be used to set status conditions after a specified
 	// time. By default a NopResource will only have a status condition of Type:
-	// Synced. It will never have a status condition of Type: Ready unless one
-	// is configured here.
+	// Synced, and a status condition of Type: Ready and Reason: Creating once
+	// its pretend external resource has been created. It will never become
+	// Ready unless a status condition of Type: Ready is configured here.
 	// +optional
 	ConditionAfter []ResourceConditionAfter `json:"conditionAfter,omitempty"`
 
+	// ConditionCycle can be used to repeatedly set status conditions. For
+	// example to make a NopResource flap between Ready and not Ready. Conditions
+	// set by the cycle take precedence over those set by ConditionAfter.
+	// +optional
+	ConditionCycle *ResourceConditionCycle `json:"conditionCycle,omitempty"`
+
+	// ConditionWhen can be used to set status conditions when fields of the
+	// NopResource have certain values. For example to make a NopResource Ready
+	// only once a field has been patched. Conditions are set each time their
+	// field matches, and take precedence over those set by ConditionAfter and
+	// ConditionCycle.
+	// +optional
+	ConditionWhen []ResourceConditionWhen `json:"conditionWhen,omitempty"`
+
+	// DependsOn are the external names of NopResources this NopResource
+	// depends on. This NopResource will have a status condition of Type: Ready,
+	// Status: False and Reason: WaitingForDependencies until all of the
+	// NopResources it depends on are Ready.
+	// +crossplane:generate:reference:type=NopResource
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// DependsOnRefs are references to NopResources used to set DependsOn.
+	// +optional
+	DependsOnRefs []xpv1.Reference `json:"dependsOnRefs,omitempty"`
+
+	// DependsOnSelector selects references to NopResources used to set
+	// DependsOnRefs.
+	// +optional
+	DependsOnSelector *xpv1.Selector `json:"dependsOnSelector,omitempty"`
+
+	// ErrorsAfter can be used to make operations on the pretend external
+	// resource return errors after a specified time. When more than one error
+	// applies to an operation the first one is returned.
+	// +optional
+	ErrorsAfter []ResourceErrorAfter `json:"errorsAfter,omitempty"`
+
 	// ConnectionDetails that this NopResource should emit on each reconcile.
 	// +optional
 	ConnectionDetails []ResourceConnectionDetail `json:"connectionDetails,omitempty"`
 
 	// Fields is an arbitrary object you can patch to and from. It has no
-	// schema, is not validated, and is not used by the NopResource controller.
+	// schema and is not validated. The NopResource's pretend external resource
+	// is updated whenever its fields differ from these.
 	// +optional
 	Fields runtime.RawExtension `json:"fields,omitempty"`
+
+	// LateInitialize is an arbitrary object that is merged into Fields the
+	// first time the pretend external resource is observed, as if it were
+	// the pretend external resource's default configuration. Only fields that
+	// are not already set are late-initialized.
+	// +optional
+	LateInitialize runtime.RawExtension `json:"lateInitialize,omitempty"`
+
+	// Observe can be used to write values derived from the NopResource to its
+	// status.atProvider.fields. Values are written in order each time the
+	// pretend external resource is observed.
+	// +optional
+	Observe []ResourceObservation `json:"observe,omitempty"`
+
+	// FieldsAfter can be used to write values to status.atProvider.fields
+	// after a specified time. For example to simulate an IP address that is
+	// only known once the pretend external resource has been provisioned. When
+	// more than one value should be written to the same field the one that
+	// occurred latest is written. Values written per FieldsAfter take
+	// precedence over those written per Observe.
+	// +optional
+	FieldsAfter []ResourceFieldAfter `json:"fieldsAfter,omitempty"`
+
+	// CreateDuration is how long the pretend external resource should take to
+	// be created. By default it is created immediately.
+	// +optional
+	CreateDuration *metav1.Duration `json:"createDuration,omitempty"`
+
+	// CreateMode determines how the pretend external resource is created.
+	// Asynchronously created pretend external resources exist, but have a
+	// status condition of Type: Ready and Reason: Creating until the create
+	// duration has passed. Synchronously created pretend external resources
+	// block the create operation until the create duration has passed, making
+	// it possible to interrupt creation by restarting the provider.
+	// +kubebuilder:default=Asynchronous
+	// +optional
+	CreateMode CreateMode `json:"createMode,omitempty"`
+
+	// ExternalNameFormat is a Go template used to generate the external name
+	// of the pretend external resource when it is created. The template is
+	// executed against the NopResource, and may use the functions randAlnum,
+	// randNumeric, randHex, uuid, lower and upper. For example
+	// "{{ .metadata.name }}-{{ randAlnum 6 }}". Random strings may be at most
+	// 1024 characters long. By default the external name is the NopResource's
+	// name.
+	// +optional
+	ExternalNameFormat *string `json:"externalNameFormat,omitempty"`
+
+	// DriftAfter is how long after it was last created or updated the
+	// pretend external resource should drift from its desired state. A
+	// drifted pretend external resource is reported as not up-to-date, and
+	// thus updated. By default the pretend external resource never drifts.
+	// +optional
+	DriftAfter *metav1.Duration `json:"driftAfter,omitempty"`
+
+	// DeletionAfter is how long the pretend external resource should continue
+	// to exist after the NopResource is deleted, measured from its deletion
+	// timestamp. The NopResource will have a status condition of Type: Ready
+	// and Reason: Deleting until then. By default the pretend external resource
+	// is deleted immediately.
+	// +optional
+	DeletionAfter *metav1.Duration `json:"deletionAfter,omitempty"`
 }
 
 // NopResourceObservation are the observable fields of a NopResource.
 type NopResourceObservation struct {
 	// Fields is an arbitrary object you can patch to and from. It has no
-	// schema, is not validated, and is not used by the NopResource controller.
+	// schema and is not validated. The NopResource controller writes to it
+	// per spec.forProvider.observe and spec.forProvider.fieldsAfter.
 	// +optional
 	Fields runtime.RawExtension `json:"fields,omitempty"`
+
+	// UpdateCount is the number of times the pretend external resource has
+	// been updated.
+	// +optional
+	UpdateCount int64 `json:"updateCount,omitempty"`
+
+	// LastUpdateTime is the time at which the pretend external resource was
+	// last updated.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// LastRotationTime is the time at which a generated connection detail of
+	// the pretend external resource was last rotated.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the NopResource's
+	// spec that was observed.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSpecChangeTime is the time at which a change to the NopResource's
+	// spec was last observed. It is unset if the spec never changed.
+	// +optional
+	LastSpecChangeTime *metav1.Time `json:"lastSpecChangeTime,omitempty"`
+
+	// ObservedSpecHash is a hash of the NopResource's spec as of
+	// ObservedGeneration, excluding any fields written by the provider. It's
+	// used to tell changes to the spec from changes made by the provider.
+	// +optional
+	ObservedSpecHash string `json:"observedSpecHash,omitempty"`
 }
 
 // A NopResourceSpec defines the desired state of a NopResource.
 type NopResourceSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       NopResourceParameters `json:"forProvider"`
+
+	// InitProvider configures the pretend external resource only when it is
+	// created. It's useful for testing partial management, for example when
+	// fields are managed by something other than Crossplane after creation.
+	// +optional
+	InitProvider NopResourceInitParameters `json:"initProvider,omitempty"`
 }
 
 // A NopResourceStatus represents the observed state of a NopResource.
@@ -165,6 +165,55 @@ echo_step "waiting for provider to be installed"
 
 kubectl wait "provider.pkg.crossplane.io/${PACKAGE_NAME}" --for=condition=healthy --timeout=180s
 
+echo_step "creating a NopResource that uses a ProviderConfig"
+
+NOP_YAML="$( cat <<EOF
+apiVersion: nop.crossplane.io/v1alpha1
+kind: ProviderConfig
+metadata:
+  name: default
+spec:
+  credentials:
+    source: None
+---
+apiVersion: nop.crossplane.io/v1alpha1
+kind: NopResource
+metadata:
+  name: integration-test
+spec:
+  forProvider:
+    conditionAfter:
+    - time: 1s
+      conditionType: Ready
+      conditionStatus: "True"
+  providerConfigRef:
+    name: default
+EOF
+)"
+
+# The provider's CRDs may not be established as soon as it's healthy.
+timeout=60
+current=0
+step=3
+until echo "${NOP_YAML}" | "${KUBECTL}" apply -f -; do
+  echo "waiting to create NopResource for another $step seconds"
+  current=$((current+step))
+  if ! [[ $timeout -gt $current ]]; then
+    echo_error "timeout of ${timeout}s has been reached"
+  fi
+  sleep $step;
+done
+
+"${KUBECTL}" wait nopresource.nop.crossplane.io/integration-test --for=condition=ready --timeout=120s
+
+echo_step "checking the ProviderConfig is in use"
+
+"${KUBECTL}" get providerconfigusage.nop.crossplane.io -o name | grep -q . || echo_error "no ProviderConfigUsage was created"
+
+echo_step "deleting the NopResource and ProviderConfig"
+
+echo "${NOP_YAML}" | "${KUBECTL}" delete -f - --wait --timeout=120s
+
 echo_step "uninstalling ${PROJECT_NAME}"
 
 echo "${INSTALL_YAML}" | "${KUBECTL}" delete -f -
@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	"github.com/crossplane-contrib/provider-nop/internal/controller/config"
 	"github.com/crossplane-contrib/provider-nop/internal/controller/nopresource"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -27,6 +28,7 @@ import (
 // the supplied manager.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	for _, setup := range []func(ctrl.Manager, controller.Options) error{
+		config.Setup,
 		nopresource.Setup,
 	} {
 		if err := setup(mgr, o); err != nil {
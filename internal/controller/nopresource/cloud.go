@@ -0,0 +1,159 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// An externalResource is the pretend external resource represented by a
+// NopResource.
+type externalResource struct {
+	// CreatedAt is the time at which the external resource was created.
+	CreatedAt time.Time
+
+	// UpdatedAt is the time at which the external resource was last updated.
+	// It is zero if the external resource has never been updated.
+	UpdatedAt time.Time
+
+	// Updates is the number of times the external resource has been updated.
+	Updates int64
+
+	// Fields are the spec.forProvider.fields the external resource was most
+	// recently created or updated with.
+	Fields runtime.RawExtension
+
+	// Secrets are the generated values of the external resource's connection
+	// details, keyed by connection detail name.
+	Secrets map[string]secret
+
+	// RotatedAt is the time at which a secret was last rotated. It is zero if
+	// no secret has ever been rotated.
+	RotatedAt time.Time
+
+	// Imported is true if the external resource was imported from a
+	// NopExternalResource, rather than created by a NopResource.
+	Imported bool
+
+	// ConnectionDetails are the connection details of an imported external
+	// resource.
+	ConnectionDetails map[string][]byte
+}
+
+// A secret is a generated connection detail value.
+type secret struct {
+	// Values of the secret, keyed by connection detail name. Some generated
+	// connection details, like key pairs, have more than one value. Values is
+	// nil if the secret was generated but its values were since lost.
+	Values map[string][]byte
+
+	// GeneratedAt is the time at which the secret was generated.
+	GeneratedAt time.Time
+}
+
+// Lost returns true if the secret was generated, but its values were since
+// lost. This happens when the provider restarts.
+func (s secret) Lost() bool {
+	return s.Values == nil
+}
+
+// UpToDate returns true if the supplied fields are semantically equal to those
+// of the external resource, and the external resource has not drifted.
+func (r externalResource) UpToDate(fields runtime.RawExtension, driftAfter time.Duration) bool {
+	if driftAfter > 0 && time.Since(r.LastChanged()) >= driftAfter {
+		return false
+	}
+	return fieldsEqual(r.Fields, fields)
+}
+
+// LastChanged returns the time at which the external resource was last
+// created or updated.
+func (r externalResource) LastChanged() time.Time {
+	if r.UpdatedAt.After(r.CreatedAt) {
+		return r.UpdatedAt
+	}
+	return r.CreatedAt
+}
+
+func fieldsEqual(a, b runtime.RawExtension) bool {
+	var av, bv any
+	if len(a.Raw) > 0 {
+		if err := json.Unmarshal(a.Raw, &av); err != nil {
+			return false
+		}
+	}
+	if len(b.Raw) > 0 {
+		if err := json.Unmarshal(b.Raw, &bv); err != nil {
+			return false
+		}
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+// A fakeCloud is an in-memory stand-in for the external system a real provider
+// would orchestrate. It stores pretend external resources by external name.
+// Its contents live only as long as the provider process does.
+type fakeCloud struct {
+	mu        sync.RWMutex
+	resources map[string]externalResource
+	calls     map[string]int64
+}
+
+func newFakeCloud() *fakeCloud {
+	return &fakeCloud{resources: map[string]externalResource{}, calls: map[string]int64{}}
+}
+
+// Get the external resource with the supplied external name, if it exists.
+func (c *fakeCloud) Get(name string) (externalResource, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	r, ok := c.resources[name]
+	return r, ok
+}
+
+// Put the supplied external resource, creating or replacing the external
+// resource with the supplied external name.
+func (c *fakeCloud) Put(name string, r externalResource) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resources[name] = r
+}
+
+// Delete the external resource with the supplied external name. It is a no-op
+// if the external resource does not exist.
+func (c *fakeCloud) Delete(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.resources, name)
+}
+
+// Call records a call identified by the supplied key, and returns how many
+// times it has been recorded.
+func (c *fakeCloud) Call(key string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.calls == nil {
+		c.calls = map[string]int64{}
+	}
+	c.calls[key]++
+	return c.calls[key]
+}
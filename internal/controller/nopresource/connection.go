@@ -0,0 +1,189 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	errFmtAmbiguousValue = "connection detail %s must set at most one of value, valueBase64, and valueFrom"
+	errFmtNoValueSource  = "connection detail %s valueFrom must set exactly one of secretKeyRef, fieldPath, and generated"
+)
+
+// connectionDetails returns the connection details the supplied NopResource
+// should emit per spec.forProvider.connectionDetails. Any values that are
+// generated or rotated are stored in the supplied external resource. It
+// returns true if the external resource was changed, and should thus be
+// stored.
+func (e *external) connectionDetails(ctx context.Context, nop *v1alpha1.NopResource, er *externalResource, now time.Time) (managed.ConnectionDetails, bool, error) {
+	cd := managed.ConnectionDetails{}
+	changed := false
+
+	var p *fieldpath.Paved
+	for _, d := range nop.Spec.ForProvider.ConnectionDetails {
+		src := d.ValueFrom
+		switch {
+		case src != nil && (d.Value != "" || d.ValueBase64 != nil),
+			d.Value != "" && d.ValueBase64 != nil:
+			return nil, false, errors.Errorf(errFmtAmbiguousValue, d.Name)
+
+		case src == nil && d.ValueBase64 != nil:
+			cd[d.Name] = d.ValueBase64
+
+		case src == nil:
+			cd[d.Name] = []byte(d.Value)
+
+		case src.SecretKeyRef != nil:
+			ref := src.SecretKeyRef
+			s := &corev1.Secret{}
+			if err := e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+				return nil, false, errors.Wrapf(err, "cannot get secret for connection detail %s", d.Name)
+			}
+			v, ok := s.Data[ref.Key]
+			if !ok {
+				return nil, false, errors.Errorf("secret %s/%s has no key %s for connection detail %s", ref.Namespace, ref.Name, ref.Key, d.Name)
+			}
+			cd[d.Name] = v
+
+		case src.FieldPath != nil:
+			if p == nil {
+				data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(nop)
+				if err != nil {
+					return nil, false, errors.Wrap(err, "cannot convert NopResource to unstructured")
+				}
+				p = fieldpath.Pave(data)
+			}
+			v, err := p.GetValue(*src.FieldPath)
+			if fieldpath.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, false, errors.Wrapf(err, "cannot get field for connection detail %s", d.Name)
+			}
+			if s, ok := v.(string); ok {
+				cd[d.Name] = []byte(s)
+				continue
+			}
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, false, errors.Wrapf(err, "cannot marshal field for connection detail %s", d.Name)
+			}
+			cd[d.Name] = b
+
+		case src.Generated != nil:
+			s, exists := er.Secrets[d.Name]
+			if exists && !s.Lost() && !rotationDue(src.Generated, s, now) {
+				for k, v := range s.Values {
+					cd[k] = v
+				}
+				continue
+			}
+			values, err := generate(nop, d.Name, src.Generated)
+			if err != nil {
+				return nil, false, errors.Wrapf(err, "cannot generate connection detail %s", d.Name)
+			}
+
+			// Copy the secrets rather than modifying them in place, since
+			// they're shared with the fake cloud.
+			secrets := make(map[string]secret, len(er.Secrets)+1)
+			for k, s := range er.Secrets {
+				secrets[k] = s
+			}
+			secrets[d.Name] = secret{Values: values, GeneratedAt: now}
+			er.Secrets = secrets
+			// Replacing a value we previously emitted is a rotation, even
+			// if we only replaced it because we lost track of it.
+			if exists {
+				er.RotatedAt = now
+			}
+			changed = true
+			for k, v := range values {
+				cd[k] = v
+			}
+
+		default:
+			return nil, false, errors.Errorf(errFmtNoValueSource, d.Name)
+		}
+	}
+	return cd, changed, nil
+}
+
+// rotationDue returns true if the supplied secret should be rotated.
+func rotationDue(g *v1alpha1.GeneratedConnectionDetail, s secret, now time.Time) bool {
+	if g.RotationPeriod == nil || g.RotationPeriod.Duration <= 0 {
+		return false
+	}
+	return now.Sub(s.GeneratedAt) >= g.RotationPeriod.Duration
+}
+
+// recoverSecrets returns the generated connection details the supplied
+// NopResource previously wrote to its connection secret, keyed by connection
+// detail name. It's used to keep generated values stable when the pretend
+// external resource they were stored with is lost. We don't know exactly when
+// a recovered value was generated, so we assume it was generated when the
+// pretend external resource was created or last rotated. Values that can't be
+// recovered are returned as lost secrets, with no values.
+func (e *external) recoverSecrets(ctx context.Context, nop *v1alpha1.NopResource, created time.Time) (map[string]secret, error) {
+	data := map[string][]byte{}
+	if ref := nop.GetWriteConnectionSecretToReference(); ref != nil {
+		s := &corev1.Secret{}
+		if err := e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); resource.IgnoreNotFound(err) != nil {
+			return nil, errors.Wrap(err, "cannot get connection secret")
+		}
+		data = s.Data
+	}
+
+	generatedAt := created
+	if t := nop.Status.AtProvider.LastRotationTime; t != nil && t.After(generatedAt) {
+		generatedAt = t.Time
+	}
+
+	secrets := map[string]secret{}
+	for _, d := range nop.Spec.ForProvider.ConnectionDetails {
+		if d.ValueFrom == nil || d.ValueFrom.Generated == nil {
+			continue
+		}
+		keys := generatedKeys(d.Name, d.ValueFrom.Generated)
+		values := make(map[string][]byte, len(keys))
+		for _, k := range keys {
+			if v, ok := data[k]; ok {
+				values[k] = v
+			}
+		}
+
+		// We can only recover values that were completely written.
+		if len(values) != len(keys) {
+			secrets[d.Name] = secret{}
+			continue
+		}
+		secrets[d.Name] = secret{Values: values, GeneratedAt: generatedAt}
+	}
+	return secrets, nil
+}
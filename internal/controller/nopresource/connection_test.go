@@ -0,0 +1,239 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestConnectionDetails(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Now()
+
+	getSecret := func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		obj.(*corev1.Secret).Data = map[string][]byte{"password": []byte("fromsecret")}
+		return nil
+	}
+
+	nop := func(cd ...v1alpha1.ResourceConnectionDetail) *v1alpha1.NopResource {
+		return &v1alpha1.NopResource{Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+			Fields:            runtime.RawExtension{Raw: []byte(`{"port":5432,"host":"example.org"}`)},
+			ConnectionDetails: cd,
+		}}}
+	}
+
+	secretKeyRef := func(key string) *v1alpha1.ConnectionDetailSource {
+		return &v1alpha1.ConnectionDetailSource{SecretKeyRef: &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Namespace: "default", Name: "db"},
+			Key:             key,
+		}}
+	}
+
+	type args struct {
+		kube client.Client
+		nop  *v1alpha1.NopResource
+		er   externalResource
+	}
+	type want struct {
+		cd      managed.ConnectionDetails
+		changed bool
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Value": {
+			reason: "We should emit literal values.",
+			args: args{
+				nop: nop(v1alpha1.ResourceConnectionDetail{Name: "username", Value: "cool"}),
+			},
+			want: want{cd: managed.ConnectionDetails{"username": []byte("cool")}},
+		},
+		"AmbiguousValue": {
+			reason: "We should return an error if a connection detail sets both a value and a value source.",
+			args: args{
+				nop: nop(v1alpha1.ResourceConnectionDetail{Name: "password", Value: "cool", ValueFrom: secretKeyRef("password")}),
+			},
+			want: want{err: errors.Errorf(errFmtAmbiguousValue, "password")},
+		},
+		"NoValueSource": {
+			reason: "We should return an error rather than silently skip a connection detail with an empty value source.",
+			args: args{
+				nop: nop(v1alpha1.ResourceConnectionDetail{Name: "password", ValueFrom: &v1alpha1.ConnectionDetailSource{}}),
+			},
+			want: want{err: errors.Errorf(errFmtNoValueSource, "password")},
+		},
+		"ValueBase64": {
+			reason: "We should emit base64 encoded values byte for byte.",
+			args: args{
+				nop: nop(v1alpha1.ResourceConnectionDetail{Name: "keystore", ValueBase64: []byte{0x00, 0xfe, 0xed}}),
+			},
+			want: want{cd: managed.ConnectionDetails{"keystore": []byte{0x00, 0xfe, 0xed}}},
+		},
+		"SecretKeyRef": {
+			reason: "We should emit values from the referenced secret key.",
+			args: args{
+				kube: &test.MockClient{MockGet: getSecret},
+				nop:  nop(v1alpha1.ResourceConnectionDetail{Name: "password", ValueFrom: secretKeyRef("password")}),
+			},
+			want: want{cd: managed.ConnectionDetails{"password": []byte("fromsecret")}},
+		},
+		"SecretGetError": {
+			reason: "We should return any error encountered getting the referenced secret.",
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				nop:  nop(v1alpha1.ResourceConnectionDetail{Name: "password", ValueFrom: secretKeyRef("password")}),
+			},
+			want: want{err: errors.Wrap(errBoom, "cannot get secret for connection detail password")},
+		},
+		"SecretKeyMissing": {
+			reason: "We should return an error if the referenced secret key does not exist.",
+			args: args{
+				kube: &test.MockClient{MockGet: getSecret},
+				nop:  nop(v1alpha1.ResourceConnectionDetail{Name: "password", ValueFrom: secretKeyRef("nope")}),
+			},
+			want: want{err: errors.New("secret default/db has no key nope for connection detail password")},
+		},
+		"FieldPath": {
+			reason: "We should emit strings as is, and other values as JSON.",
+			args: args{
+				nop: nop(
+					v1alpha1.ResourceConnectionDetail{Name: "host", ValueFrom: &v1alpha1.ConnectionDetailSource{FieldPath: ptr.To("spec.forProvider.fields.host")}},
+					v1alpha1.ResourceConnectionDetail{Name: "port", ValueFrom: &v1alpha1.ConnectionDetailSource{FieldPath: ptr.To("spec.forProvider.fields.port")}},
+					v1alpha1.ResourceConnectionDetail{Name: "missing", ValueFrom: &v1alpha1.ConnectionDetailSource{FieldPath: ptr.To("spec.forProvider.fields.missing")}},
+				),
+			},
+			want: want{cd: managed.ConnectionDetails{"host": []byte("example.org"), "port": []byte("5432")}},
+		},
+		"GeneratedExisting": {
+			reason: "We should emit previously generated values.",
+			args: args{
+				nop: nop(v1alpha1.ResourceConnectionDetail{Name: "password", ValueFrom: &v1alpha1.ConnectionDetailSource{Generated: &v1alpha1.GeneratedConnectionDetail{}}}),
+				er:  externalResource{Secrets: map[string]secret{"password": {Values: map[string][]byte{"password": []byte("generated")}, GeneratedAt: now}}},
+			},
+			want: want{cd: managed.ConnectionDetails{"password": []byte("generated")}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.args.kube, cloud: newFakeCloud()}
+			cd, changed, err := e.connectionDetails(context.Background(), tc.args.nop, &tc.args.er, now)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.connectionDetails(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cd, cd); diff != "" {
+				t.Errorf("\n%s\ne.connectionDetails(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if changed != tc.want.changed {
+				t.Errorf("\n%s\ne.connectionDetails(...): want changed %t, got %t\n", tc.reason, tc.want.changed, changed)
+			}
+		})
+	}
+
+	t.Run("GeneratedNew", func(t *testing.T) {
+		e := &external{cloud: newFakeCloud()}
+		n := nop(v1alpha1.ResourceConnectionDetail{Name: "password", ValueFrom: &v1alpha1.ConnectionDetailSource{
+			Generated: &v1alpha1.GeneratedConnectionDetail{Length: ptr.To[int32](12)},
+		}})
+		shared := map[string]secret{"other": {Values: map[string][]byte{"other": []byte("cool")}}}
+		er := externalResource{Secrets: shared}
+
+		cd, changed, err := e.connectionDetails(context.Background(), n, &er, now)
+		if err != nil {
+			t.Fatalf("e.connectionDetails(...): %s", err)
+		}
+		if !changed {
+			t.Errorf("e.connectionDetails(...): want changed to be true when a value is generated")
+		}
+		if len(cd["password"]) != 12 {
+			t.Errorf("e.connectionDetails(...): want a 12 character password, got %q", cd["password"])
+		}
+		if diff := cmp.Diff(secret{Values: map[string][]byte{"password": cd["password"]}, GeneratedAt: now}, er.Secrets["password"]); diff != "" {
+			t.Errorf("e.connectionDetails(...): want generated value to be stored: -want, +got:\n%s", diff)
+		}
+		if _, ok := shared["password"]; ok {
+			t.Errorf("e.connectionDetails(...): want secrets to be copied, not modified in place")
+		}
+	})
+
+	t.Run("Rotation", func(t *testing.T) {
+		e := &external{cloud: newFakeCloud()}
+		n := nop(v1alpha1.ResourceConnectionDetail{Name: "password", ValueFrom: &v1alpha1.ConnectionDetailSource{
+			Generated: &v1alpha1.GeneratedConnectionDetail{RotationPeriod: &metav1.Duration{Duration: time.Hour}},
+		}})
+
+		old := func(generatedAt time.Time) secret {
+			return secret{Values: map[string][]byte{"password": []byte("old")}, GeneratedAt: generatedAt}
+		}
+
+		cases := map[string]struct {
+			reason  string
+			s       secret
+			rotated bool
+		}{
+			"NotDue": {
+				reason:  "We should not rotate a value before its rotation period has passed.",
+				s:       old(now.Add(-30 * time.Minute)),
+				rotated: false,
+			},
+			"Due": {
+				reason:  "We should rotate a value once its rotation period has passed.",
+				s:       old(now.Add(-2 * time.Hour)),
+				rotated: true,
+			},
+			"Lost": {
+				reason:  "We should record replacing a value we lost track of as a rotation.",
+				s:       secret{},
+				rotated: true,
+			},
+		}
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				er := externalResource{Secrets: map[string]secret{"password": tc.s}}
+				cd, changed, err := e.connectionDetails(context.Background(), n, &er, now)
+				if err != nil {
+					t.Fatalf("\n%s\ne.connectionDetails(...): %s", tc.reason, err)
+				}
+				if changed != tc.rotated || (string(cd["password"]) != "old") != tc.rotated {
+					t.Errorf("\n%s\ne.connectionDetails(...): want rotated %t, got changed %t and value %q", tc.reason, tc.rotated, changed, cd["password"])
+				}
+				if got := er.RotatedAt.Equal(now); got != tc.rotated {
+					t.Errorf("\n%s\ne.connectionDetails(...): want rotation time recorded %t, got %t", tc.reason, tc.rotated, got)
+				}
+			})
+		}
+	})
+}
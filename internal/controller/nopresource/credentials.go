@@ -0,0 +1,67 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	errGetCreds         = "cannot get credentials"
+	errGetExpectedCreds = "cannot get expected credentials"
+	errInvalidCreds     = "InvalidClientTokenId: the security token included in the request is invalid"
+)
+
+// authenticate to the fake cloud using the supplied credentials. Credentials
+// are extracted from their source just like a real provider would. They're
+// only validated if the fake cloud has been told what credentials to expect.
+func (c *connecter) authenticate(ctx context.Context, pc v1alpha1.ProviderCredentials) error {
+	src := pc.Source
+	if src == "" {
+		src = xpv1.CredentialsSourceNone
+	}
+
+	creds, err := resource.CommonCredentialExtractor(ctx, src, c.kube, pc.CommonCredentialSelectors)
+	if err != nil {
+		return errors.Wrap(err, errGetCreds)
+	}
+
+	ref := pc.ExpectedSecretRef
+	if ref == nil {
+		return nil
+	}
+
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return errors.Wrap(err, errGetExpectedCreds)
+	}
+
+	if subtle.ConstantTimeCompare(creds, s.Data[ref.Key]) != 1 {
+		return errors.New(errInvalidCreds)
+	}
+
+	return nil
+}
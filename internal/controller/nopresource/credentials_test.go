@@ -0,0 +1,139 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestAuthenticate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	fromSecret := xpv1.CommonCredentialSelectors{
+		SecretRef: &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "creds"},
+			Key:             "credentials",
+		},
+	}
+	expected := &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "expected"},
+		Key:             "credentials",
+	}
+
+	// secrets returns a MockGetFn that gets Secrets from the supplied map of
+	// names to credentials.
+	secrets := func(creds map[string]string) test.MockGetFn {
+		return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			v, ok := creds[key.Name]
+			if !ok {
+				return errBoom
+			}
+			obj.(*corev1.Secret).Data = map[string][]byte{"credentials": []byte(v)}
+			return nil
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		creds  v1alpha1.ProviderCredentials
+		want   error
+	}{
+		"NoCredentials": {
+			reason: "We should not require credentials by default.",
+			creds:  v1alpha1.ProviderCredentials{},
+			want:   nil,
+		},
+		"ExtractError": {
+			reason: "We should return any error encountered extracting credentials.",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			creds: v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: fromSecret,
+			},
+			want: errors.Wrap(errors.Wrap(errBoom, "cannot get credentials secret"), errGetCreds),
+		},
+		"NotValidated": {
+			reason: "We should accept any credentials if no expected credentials are configured.",
+			kube:   &test.MockClient{MockGet: secrets(map[string]string{"creds": "cool"})},
+			creds: v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: fromSecret,
+			},
+			want: nil,
+		},
+		"GetExpectedError": {
+			reason: "We should return any error encountered getting the expected credentials.",
+			kube:   &test.MockClient{MockGet: secrets(map[string]string{"creds": "cool"})},
+			creds: v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: fromSecret,
+				ExpectedSecretRef:         expected,
+			},
+			want: errors.Wrap(errBoom, errGetExpectedCreds),
+		},
+		"MissingCredentials": {
+			reason: "We should reject an empty credential if credentials are expected.",
+			kube:   &test.MockClient{MockGet: secrets(map[string]string{"expected": "cool"})},
+			creds: v1alpha1.ProviderCredentials{
+				Source:            xpv1.CredentialsSourceNone,
+				ExpectedSecretRef: expected,
+			},
+			want: errors.New(errInvalidCreds),
+		},
+		"InvalidCredentials": {
+			reason: "We should reject credentials that don't match the expected credentials.",
+			kube:   &test.MockClient{MockGet: secrets(map[string]string{"creds": "lame", "expected": "cool"})},
+			creds: v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: fromSecret,
+				ExpectedSecretRef:         expected,
+			},
+			want: errors.New(errInvalidCreds),
+		},
+		"ValidCredentials": {
+			reason: "We should accept credentials that match the expected credentials.",
+			kube:   &test.MockClient{MockGet: secrets(map[string]string{"creds": "cool", "expected": "cool"})},
+			creds: v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: fromSecret,
+				ExpectedSecretRef:         expected,
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &connecter{kube: tc.kube}
+			err := c.authenticate(context.Background(), tc.creds)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.authenticate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
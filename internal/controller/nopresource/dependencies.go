@@ -0,0 +1,81 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+)
+
+const (
+	// ReasonWaitingForDependencies indicates that a NopResource is not Ready
+	// because some of the NopResources it depends on are not Ready.
+	ReasonWaitingForDependencies xpv1.ConditionReason = "WaitingForDependencies"
+)
+
+const (
+	errListDependencies = "cannot list NopResources to determine whether dependencies are ready"
+)
+
+// waitForDependencies sets the supplied NopResource's Ready condition to False
+// if any of the NopResources it depends on are not Ready.
+func (e *external) waitForDependencies(ctx context.Context, nop *v1alpha1.NopResource) error {
+	if len(nop.Spec.ForProvider.DependsOn) == 0 {
+		return nil
+	}
+
+	// We depend on NopResources by external name, so we need to list them all
+	// to find the ones we depend on.
+	l := &v1alpha1.NopResourceList{}
+	if err := e.kube.List(ctx, l); err != nil {
+		return errors.Wrap(err, errListDependencies)
+	}
+
+	ready := map[string]bool{}
+	for i := range l.Items {
+		ready[meta.GetExternalName(&l.Items[i])] = l.Items[i].GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue
+	}
+
+	waiting := make([]string, 0, len(nop.Spec.ForProvider.DependsOn))
+	for _, name := range nop.Spec.ForProvider.DependsOn {
+		if !ready[name] {
+			waiting = append(waiting, name)
+		}
+	}
+
+	if len(waiting) == 0 {
+		return nil
+	}
+
+	nop.SetConditions(xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		Reason:             ReasonWaitingForDependencies,
+		Message:            fmt.Sprintf("Waiting for NopResources with external names %s to be Ready", strings.Join(waiting, ", ")),
+		LastTransitionTime: metav1.Now(),
+	})
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestWaitForDependencies(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	dependency := func(name string, c xpv1.Condition) v1alpha1.NopResource {
+		d := v1alpha1.NopResource{}
+		meta.SetExternalName(&d, name)
+		d.SetConditions(c)
+		return d
+	}
+
+	list := func(items ...v1alpha1.NopResource) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			obj.(*v1alpha1.NopResourceList).Items = items
+			return nil
+		}
+	}
+
+	type want struct {
+		conditions []xpv1.Condition
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		nop    *v1alpha1.NopResource
+		want   want
+	}{
+		"NoDependencies": {
+			reason: "We should not list NopResources or set conditions when a NopResource has no dependencies.",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			nop:    &v1alpha1.NopResource{},
+			want:   want{},
+		},
+		"ListError": {
+			reason: "We should return any error encountered listing NopResources.",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			nop: &v1alpha1.NopResource{Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+				DependsOn: []string{"vpc"},
+			}}},
+			want: want{err: errors.Wrap(errBoom, errListDependencies)},
+		},
+		"DependenciesReady": {
+			reason: "We should not set conditions when all dependencies are Ready.",
+			kube:   &test.MockClient{MockList: list(dependency("vpc", xpv1.Available()), dependency("subnet", xpv1.Available()))},
+			nop: &v1alpha1.NopResource{Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+				DependsOn: []string{"vpc", "subnet"},
+			}}},
+			want: want{},
+		},
+		"DependenciesNotReady": {
+			reason: "We should set Ready to False when some dependencies are not Ready or do not exist.",
+			kube:   &test.MockClient{MockList: list(dependency("vpc", xpv1.Available()), dependency("subnet", xpv1.Creating()))},
+			nop: &v1alpha1.NopResource{Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+				DependsOn: []string{"vpc", "subnet", "gateway"},
+			}}},
+			want: want{conditions: []xpv1.Condition{{
+				Type:    xpv1.TypeReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  ReasonWaitingForDependencies,
+				Message: "Waiting for NopResources with external names subnet, gateway to be Ready",
+			}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{kube: tc.kube, cloud: newFakeCloud()}
+			err := e.waitForDependencies(context.Background(), tc.nop)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.waitForDependencies(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			// Ignore transition times, which we can't predict.
+			for i := range tc.nop.Status.Conditions {
+				tc.nop.Status.Conditions[i].LastTransitionTime = metav1.Time{}
+			}
+			if diff := cmp.Diff(tc.want.conditions, tc.nop.Status.Conditions, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.waitForDependencies(...): -want conditions, +got conditions:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
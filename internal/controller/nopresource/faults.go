@@ -0,0 +1,64 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/pkg/errors"
+)
+
+const (
+	errOutage    = "ServiceUnavailable: the fake cloud is experiencing an outage"
+	errErrorRate = "InternalError: the fake cloud encountered an internal error"
+)
+
+// injectedError returns the error the supplied operation should return per
+// the ProviderConfig or spec.forProvider.errorsAfter, if any.
+func (e *external) injectedError(nop *v1alpha1.NopResource, op v1alpha1.ExternalOperation) error {
+	if e.config.Outage {
+		return errors.New(errOutage)
+	}
+
+	if r := e.config.ErrorRatePercent; r != nil && rand.Float64()*100 < float64(*r) { //nolint:gosec // These values needn't be cryptographically secure.
+		return errors.New(errErrorRate)
+	}
+
+	age := time.Since(nop.GetCreationTimestamp().Time)
+	for i, ea := range nop.Spec.ForProvider.ErrorsAfter {
+		if ea.Operation != op || ea.Time.Duration > age {
+			// This error should not occur yet.
+			continue
+		}
+
+		if ea.Duration != nil && age >= ea.Time.Duration+ea.Duration.Duration {
+			// This error should no longer occur.
+			continue
+		}
+
+		// We only count calls that happen while this error applies.
+		if ea.Count != nil && e.cloud.Call(fmt.Sprintf("%s/errorsAfter/%d", nop.GetUID(), i)) > *ea.Count {
+			continue
+		}
+
+		return errors.New(ea.ErrorMessage)
+	}
+	return nil
+}
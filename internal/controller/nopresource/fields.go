@@ -0,0 +1,157 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+)
+
+// observeFields writes values to the supplied NopResource's
+// status.atProvider.fields per spec.forProvider.observe.
+func observeFields(nop *v1alpha1.NopResource) error {
+	if len(nop.Spec.ForProvider.Observe) == 0 {
+		return nil
+	}
+
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(nop)
+	if err != nil {
+		return errors.Wrap(err, "cannot convert NopResource to unstructured")
+	}
+	from := fieldpath.Pave(data)
+
+	to, err := statusFields(nop)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range nop.Spec.ForProvider.Observe {
+		v, ok, err := observe(from, nop, o)
+		if err != nil {
+			return errors.Wrapf(err, "cannot observe value for field %s", o.ToFieldPath)
+		}
+		if !ok {
+			continue
+		}
+		if err := to.SetValue(o.ToFieldPath, v); err != nil {
+			return errors.Wrapf(err, "cannot set field %s", o.ToFieldPath)
+		}
+	}
+
+	return setStatusFields(nop, to)
+}
+
+// observe returns the value the supplied observation should write. It returns
+// false if there is no value to write.
+func observe(p *fieldpath.Paved, nop *v1alpha1.NopResource, o v1alpha1.ResourceObservation) (any, bool, error) {
+	switch {
+	case o.FromFieldPath != nil:
+		v, err := p.GetValue(*o.FromFieldPath)
+		if fieldpath.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return v, err == nil, err
+	case o.Template != nil:
+		s, err := render(*o.Template, nop)
+		if err != nil {
+			return nil, false, err
+		}
+		if o.Format != v1alpha1.ObservationFormatJSON {
+			return s, true, nil
+		}
+		var v any
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return nil, false, errors.Wrap(err, "cannot parse template output as JSON")
+		}
+		return v, true, nil
+	}
+	return nil, false, errors.New("one of fromFieldPath or template is required")
+}
+
+// setScheduledFields writes the most recent values that should be written to
+// the supplied NopResource's status.atProvider.fields per
+// spec.forProvider.fieldsAfter.
+func setScheduledFields(nop *v1alpha1.NopResource, now time.Time) error {
+	type occurrence struct {
+		at time.Time
+		fa v1alpha1.ResourceFieldAfter
+	}
+
+	occurred := make([]occurrence, 0, len(nop.Spec.ForProvider.FieldsAfter))
+	for _, fa := range nop.Spec.ForProvider.FieldsAfter {
+		t, ok := anchorTime(nop, fa.Anchor)
+		if !ok {
+			// This value's anchor event hasn't happened yet.
+			continue
+		}
+		at := t.Add(fa.Time.Duration)
+		if at.After(now) {
+			// This value should not be written yet.
+			continue
+		}
+		occurred = append(occurred, occurrence{at: at, fa: fa})
+	}
+
+	if len(occurred) == 0 {
+		return nil
+	}
+
+	// Sort values, with those that occurred latest appearing last so that
+	// they're written last.
+	sort.SliceStable(occurred, func(i, j int) bool {
+		return occurred[i].at.Before(occurred[j].at)
+	})
+
+	to, err := statusFields(nop)
+	if err != nil {
+		return err
+	}
+	for _, o := range occurred {
+		if err := to.SetValue(o.fa.FieldPath, parse(o.fa.Value)); err != nil {
+			return errors.Wrapf(err, "cannot set field %s", o.fa.FieldPath)
+		}
+	}
+	return setStatusFields(nop, to)
+}
+
+// statusFields returns the supplied NopResource's status.atProvider.fields.
+func statusFields(nop *v1alpha1.NopResource) (*fieldpath.Paved, error) {
+	fields := map[string]any{}
+	if len(nop.Status.AtProvider.Fields.Raw) > 0 {
+		if err := json.Unmarshal(nop.Status.AtProvider.Fields.Raw, &fields); err != nil {
+			return nil, errors.Wrap(err, "cannot unmarshal status.atProvider.fields")
+		}
+	}
+	return fieldpath.Pave(fields), nil
+}
+
+// setStatusFields sets the supplied NopResource's status.atProvider.fields.
+func setStatusFields(nop *v1alpha1.NopResource, p *fieldpath.Paved) error {
+	raw, err := json.Marshal(p.UnstructuredContent())
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal status.atProvider.fields")
+	}
+	nop.Status.AtProvider.Fields = runtime.RawExtension{Raw: raw}
+	return nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestObserveFields(t *testing.T) {
+	nop := func(spec, status string, o ...v1alpha1.ResourceObservation) *v1alpha1.NopResource {
+		nop := &v1alpha1.NopResource{
+			ObjectMeta: metav1.ObjectMeta{Name: "cool"},
+			Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+				Fields:  runtime.RawExtension{Raw: []byte(spec)},
+				Observe: o,
+			}},
+		}
+		if status != "" {
+			nop.Status.AtProvider.Fields = runtime.RawExtension{Raw: []byte(status)}
+		}
+		return nop
+	}
+
+	type want struct {
+		fields string
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		nop    *v1alpha1.NopResource
+		want   want
+	}{
+		"NoObservations": {
+			reason: "We should not touch status fields if there are no observations.",
+			nop:    nop(`{}`, `{"patched":"cool"}`),
+			want:   want{fields: `{"patched":"cool"}`},
+		},
+		"FromFieldPath": {
+			reason: "We should copy values from the supplied field path, preserving other status fields.",
+			nop: nop(`{"replicas":3}`, `{"patched":"cool"}`, v1alpha1.ResourceObservation{
+				ToFieldPath:   "scale.replicas",
+				FromFieldPath: ptr.To("spec.forProvider.fields.replicas"),
+			}),
+			want: want{fields: `{"patched":"cool","scale":{"replicas":3}}`},
+		},
+		"FromMissingFieldPath": {
+			reason: "We should not write anything if the field to copy from does not exist.",
+			nop: nop(`{}`, ``, v1alpha1.ResourceObservation{
+				ToFieldPath:   "replicas",
+				FromFieldPath: ptr.To("spec.forProvider.fields.replicas"),
+			}),
+			want: want{fields: `{}`},
+		},
+		"Template": {
+			reason: "We should write the rendered template as a string.",
+			nop: nop(`{}`, ``, v1alpha1.ResourceObservation{
+				ToFieldPath: "endpoints[0]",
+				Template:    ptr.To("{{ .metadata.name }}.example.org"),
+			}),
+			want: want{fields: `{"endpoints":["cool.example.org"]}`},
+		},
+		"TemplateJSON": {
+			reason: "We should parse the rendered template as JSON if asked to.",
+			nop: nop(`{"replicas":3}`, ``, v1alpha1.ResourceObservation{
+				ToFieldPath: "ready",
+				Template:    ptr.To(`{{ if eq .spec.forProvider.fields.replicas 3 }}true{{ else }}false{{ end }}`),
+				Format:      v1alpha1.ObservationFormatJSON,
+			}),
+			want: want{fields: `{"ready":true}`},
+		},
+		"NoSource": {
+			reason: "We should return an error if an observation has neither a field path nor a template.",
+			nop:    nop(`{}`, ``, v1alpha1.ResourceObservation{ToFieldPath: "cool"}),
+			want: want{err: errors.Wrap(
+				errors.New("one of fromFieldPath or template is required"),
+				"cannot observe value for field cool",
+			)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := observeFields(tc.nop)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nobserveFields(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.fields, string(tc.nop.Status.AtProvider.Fields.Raw)); diff != "" {
+				t.Errorf("\n%s\nobserveFields(...): -want fields, +got fields:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetScheduledFields(t *testing.T) {
+	now := time.Now()
+
+	nop := func(fa ...v1alpha1.ResourceFieldAfter) *v1alpha1.NopResource {
+		return &v1alpha1.NopResource{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-1 * time.Minute))},
+			Spec:       v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{FieldsAfter: fa}},
+		}
+	}
+
+	after := func(d time.Duration, fieldPath, value string) v1alpha1.ResourceFieldAfter {
+		return v1alpha1.ResourceFieldAfter{Time: metav1.Duration{Duration: d}, FieldPath: fieldPath, Value: value}
+	}
+
+	cases := map[string]struct {
+		reason string
+		nop    *v1alpha1.NopResource
+		want   string
+	}{
+		"NotYet": {
+			reason: "We should not write values whose time has not yet passed.",
+			nop:    nop(after(2*time.Minute, "ip", "10.0.0.1")),
+			want:   "",
+		},
+		"Elapsed": {
+			reason: "We should write values whose time has passed, parsing them as JSON where possible.",
+			nop: nop(
+				after(10*time.Second, "ip", "10.0.0.1"),
+				after(20*time.Second, "network.ports[0]", "443"),
+				after(2*time.Minute, "id", "late"),
+			),
+			want: `{"ip":"10.0.0.1","network":{"ports":[443]}}`,
+		},
+		"Latest": {
+			reason: "We should write the value that occurred latest when several values share a field.",
+			nop: nop(
+				after(30*time.Second, "phase", "Running"),
+				after(10*time.Second, "phase", "Pending"),
+			),
+			want: `{"phase":"Running"}`,
+		},
+		"NotDeleted": {
+			reason: "We should not write values anchored to deletion if the NopResource has not been deleted.",
+			nop: nop(v1alpha1.ResourceFieldAfter{
+				Anchor:    v1alpha1.ScheduleAnchorDeletion,
+				FieldPath: "phase",
+				Value:     "Terminating",
+			}),
+			want: "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := setScheduledFields(tc.nop, now); err != nil {
+				t.Fatalf("\n%s\nsetScheduledFields(...): %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, string(tc.nop.Status.AtProvider.Fields.Raw)); diff != "" {
+				t.Errorf("\n%s\nsetScheduledFields(...): -want fields, +got fields:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/password"
+)
+
+const (
+	defaultRSABits    = 2048
+	maxRSABits        = 4096
+	maxPasswordLength = 1024
+
+	// certificateValidity is how long generated certificates are valid for.
+	certificateValidity = 365 * 24 * time.Hour
+)
+
+// generate the values of the named connection detail, keyed by connection
+// detail name.
+func generate(nop *v1alpha1.NopResource, name string, g *v1alpha1.GeneratedConnectionDetail) (map[string][]byte, error) {
+	switch g.Type {
+	case v1alpha1.GeneratedTypeRSAKeyPair:
+		return generateRSAKeyPair(name, g)
+	case v1alpha1.GeneratedTypeTLSCertificate:
+		return generateTLSCertificate(nop, name, g)
+	case v1alpha1.GeneratedTypePassword:
+		return generatePassword(name, g)
+	}
+	return generatePassword(name, g)
+}
+
+// generatedKeys returns the names of the connection details generate emits for
+// the named connection detail.
+func generatedKeys(name string, g *v1alpha1.GeneratedConnectionDetail) []string {
+	switch g.Type {
+	case v1alpha1.GeneratedTypeRSAKeyPair:
+		return []string{name, name + ".pub"}
+	case v1alpha1.GeneratedTypeTLSCertificate:
+		return []string{name + ".crt", name + ".key", name + ".ca.crt"}
+	case v1alpha1.GeneratedTypePassword:
+		return []string{name}
+	}
+	return []string{name}
+}
+
+func generatePassword(name string, g *v1alpha1.GeneratedConnectionDetail) (map[string][]byte, error) {
+	s := password.Default
+	if g.Length != nil {
+		s.Length = int(*g.Length)
+	}
+	if s.Length > maxPasswordLength {
+		return nil, errors.Errorf("cannot generate passwords longer than %d characters", maxPasswordLength)
+	}
+	if g.CharacterSet != nil && *g.CharacterSet != "" {
+		s.CharacterSet = *g.CharacterSet
+	}
+	pw, err := s.Generate()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate password")
+	}
+	return map[string][]byte{name: []byte(pw)}, nil
+}
+
+func generateRSAKeyPair(name string, g *v1alpha1.GeneratedConnectionDetail) (map[string][]byte, error) {
+	bits := defaultRSABits
+	if g.Bits != nil {
+		bits = int(*g.Bits)
+	}
+	if bits > maxRSABits {
+		return nil, errors.Errorf("cannot generate RSA keys larger than %d bits", maxRSABits)
+	}
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate RSA key")
+	}
+	return map[string][]byte{
+		name:          pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		name + ".pub": authorizedKey(&key.PublicKey),
+	}, nil
+}
+
+// authorizedKey returns the supplied public key in SSH authorized_keys format.
+// See RFC 4253 section 6.6.
+func authorizedKey(k *rsa.PublicKey) []byte {
+	const typ = "ssh-rsa"
+
+	var b []byte
+	b = appendSSHString(b, []byte(typ))
+	b = appendSSHString(b, mpint(big.NewInt(int64(k.E))))
+	b = appendSSHString(b, mpint(k.N))
+	return []byte(typ + " " + base64.StdEncoding.EncodeToString(b) + "\n")
+}
+
+func appendSSHString(b, s []byte) []byte {
+	b = binary.BigEndian.AppendUint32(b, uint32(len(s))) //nolint:gosec // Keys aren't large enough to overflow.
+	return append(b, s...)
+}
+
+// mpint encodes the supplied positive integer per RFC 4251 section 5.
+func mpint(n *big.Int) []byte {
+	b := n.Bytes()
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		// Prepend a zero byte so the integer isn't interpreted as negative.
+		b = append([]byte{0}, b...)
+	}
+	return b
+}
+
+func generateTLSCertificate(nop *v1alpha1.NopResource, name string, g *v1alpha1.GeneratedConnectionDetail) (map[string][]byte, error) {
+	cn := nop.GetName()
+	if g.CommonName != nil {
+		r, err := render(*g.CommonName, nop)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot render common name")
+		}
+		cn = r
+	}
+	dnsNames := make([]string, 0, len(g.DNSNames))
+	for _, tmpl := range g.DNSNames {
+		r, err := render(tmpl, nop)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot render DNS name")
+		}
+		dnsNames = append(dnsNames, r)
+	}
+
+	now := time.Now()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate CA key")
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn + " CA", Organization: []string{"provider-nop"}},
+		NotBefore:             now,
+		NotAfter:              now.Add(certificateValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create CA certificate")
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate key")
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"provider-nop"}},
+		DNSNames:     dnsNames,
+		NotBefore:    now,
+		NotAfter:     now.Add(certificateValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caTmpl, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create certificate")
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal key")
+	}
+
+	return map[string][]byte{
+		name + ".crt":    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		name + ".key":    pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		name + ".ca.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+	}, nil
+}
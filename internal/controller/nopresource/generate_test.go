@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestGenerate(t *testing.T) {
+	nop := &v1alpha1.NopResource{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool"},
+		Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+			Fields: runtime.RawExtension{Raw: []byte(`{"host":"db.example.org"}`)},
+		}},
+	}
+
+	t.Run("Password", func(t *testing.T) {
+		got, err := generate(nop, "password", &v1alpha1.GeneratedConnectionDetail{
+			Type:         v1alpha1.GeneratedTypePassword,
+			Length:       ptr.To[int32](32),
+			CharacterSet: ptr.To("ab"),
+		})
+		if err != nil {
+			t.Fatalf("generate(...): %s", err)
+		}
+		pw := got["password"]
+		if len(pw) != 32 || strings.Trim(string(pw), "ab") != "" {
+			t.Errorf("generate(...): want a 32 character password consisting of a and b, got %q", pw)
+		}
+	})
+
+	t.Run("RSAKeyPair", func(t *testing.T) {
+		got, err := generate(nop, "id_rsa", &v1alpha1.GeneratedConnectionDetail{
+			Type: v1alpha1.GeneratedTypeRSAKeyPair,
+			Bits: ptr.To[int32](1024),
+		})
+		if err != nil {
+			t.Fatalf("generate(...): %s", err)
+		}
+
+		b, _ := pem.Decode(got["id_rsa"])
+		if b == nil {
+			t.Fatalf("generate(...): want a PEM encoded private key, got %q", got["id_rsa"])
+		}
+		key, err := x509.ParsePKCS1PrivateKey(b.Bytes)
+		if err != nil {
+			t.Fatalf("generate(...): cannot parse private key: %s", err)
+		}
+
+		fields := strings.Fields(string(got["id_rsa.pub"]))
+		if len(fields) != 2 || fields[0] != "ssh-rsa" {
+			t.Fatalf("generate(...): want an SSH authorized key, got %q", got["id_rsa.pub"])
+		}
+		wire, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil {
+			t.Fatalf("generate(...): cannot decode SSH authorized key: %s", err)
+		}
+		if !bytes.HasSuffix(wire, mpint(key.N)) {
+			t.Errorf("generate(...): want SSH authorized key to encode the private key's public modulus")
+		}
+	})
+
+	t.Run("PasswordTooLong", func(t *testing.T) {
+		_, err := generate(nop, "password", &v1alpha1.GeneratedConnectionDetail{
+			Type:   v1alpha1.GeneratedTypePassword,
+			Length: ptr.To[int32](1 << 30),
+		})
+		if err == nil {
+			t.Errorf("generate(...): want an error generating a password longer than %d characters", maxPasswordLength)
+		}
+	})
+
+	t.Run("RSAKeyPairTooLarge", func(t *testing.T) {
+		_, err := generate(nop, "id_rsa", &v1alpha1.GeneratedConnectionDetail{
+			Type: v1alpha1.GeneratedTypeRSAKeyPair,
+			Bits: ptr.To[int32](65536),
+		})
+		if err == nil {
+			t.Errorf("generate(...): want an error generating an RSA key larger than %d bits", maxRSABits)
+		}
+	})
+
+	t.Run("TLSCertificate", func(t *testing.T) {
+		got, err := generate(nop, "tls", &v1alpha1.GeneratedConnectionDetail{
+			Type:     v1alpha1.GeneratedTypeTLSCertificate,
+			DNSNames: []string{"{{ .spec.forProvider.fields.host }}", "localhost"},
+		})
+		if err != nil {
+			t.Fatalf("generate(...): %s", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(got["tls.ca.crt"]) {
+			t.Fatalf("generate(...): want a PEM encoded CA certificate, got %q", got["tls.ca.crt"])
+		}
+		b, _ := pem.Decode(got["tls.crt"])
+		if b == nil {
+			t.Fatalf("generate(...): want a PEM encoded certificate, got %q", got["tls.crt"])
+		}
+		cert, err := x509.ParseCertificate(b.Bytes)
+		if err != nil {
+			t.Fatalf("generate(...): cannot parse certificate: %s", err)
+		}
+		if diff := cmp.Diff([]string{"db.example.org", "localhost"}, cert.DNSNames); diff != "" {
+			t.Errorf("generate(...): -want DNS names, +got DNS names:\n%s", diff)
+		}
+		if cert.Subject.CommonName != "cool" {
+			t.Errorf("generate(...): want common name %q, got %q", "cool", cert.Subject.CommonName)
+		}
+		if _, err := cert.Verify(x509.VerifyOptions{DNSName: "db.example.org", Roots: pool}); err != nil {
+			t.Errorf("generate(...): want certificate signed by CA: %s", err)
+		}
+		if k, _ := pem.Decode(got["tls.key"]); k == nil {
+			t.Errorf("generate(...): want a PEM encoded private key, got %q", got["tls.key"])
+		}
+	})
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"encoding/json"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// desiredFields returns the fields the supplied NopResource's pretend external
+// resource should have, given the fields it was observed to have. These are
+// spec.forProvider.fields, plus the observed value of any field that is set
+// only in spec.initProvider.fields. Such fields are set when the pretend
+// external resource is created, and are thereafter left alone.
+func desiredFields(nop *v1alpha1.NopResource, observed runtime.RawExtension) (runtime.RawExtension, error) {
+	if len(nop.Spec.InitProvider.Fields.Raw) == 0 || len(observed.Raw) == 0 {
+		return *nop.Spec.ForProvider.Fields.DeepCopy(), nil
+	}
+
+	init := map[string]any{}
+	if err := json.Unmarshal(nop.Spec.InitProvider.Fields.Raw, &init); err != nil {
+		return runtime.RawExtension{}, errors.Wrap(err, "cannot unmarshal initProvider fields")
+	}
+	o := map[string]any{}
+	if err := json.Unmarshal(observed.Raw, &o); err != nil {
+		return runtime.RawExtension{}, errors.Wrap(err, "cannot unmarshal observed fields")
+	}
+
+	raw, err := json.Marshal(selectFields(o, init))
+	if err != nil {
+		return runtime.RawExtension{}, errors.Wrap(err, "cannot marshal observed fields")
+	}
+
+	fields, _, err := lateInitialize(nop.Spec.ForProvider.Fields, runtime.RawExtension{Raw: raw})
+	return fields, err
+}
+
+// selectFields returns the values in src that are also set in keys, recursing
+// into objects.
+func selectFields(src, keys map[string]any) map[string]any {
+	out := map[string]any{}
+	for k, kv := range keys {
+		sv, ok := src[k]
+		if !ok {
+			continue
+		}
+		km, kok := kv.(map[string]any)
+		sm, sok := sv.(map[string]any)
+		if kok && sok {
+			out[k] = selectFields(sm, km)
+			continue
+		}
+		out[k] = sv
+	}
+	return out
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"testing"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestDesiredFields(t *testing.T) {
+	type args struct {
+		forProvider  string
+		initProvider string
+		observed     string
+	}
+	type want struct {
+		fields runtime.RawExtension
+		err    error
+	}
+
+	raw := func(s string) runtime.RawExtension {
+		if s == "" {
+			return runtime.RawExtension{}
+		}
+		return runtime.RawExtension{Raw: []byte(s)}
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoInitProvider": {
+			reason: "We should desire spec.forProvider.fields if spec.initProvider.fields is unset.",
+			args: args{
+				forProvider: `{"cool":true}`,
+				observed:    `{"cool":false,"replicas":5}`,
+			},
+			want: want{
+				fields: raw(`{"cool":true}`),
+			},
+		},
+		"ForProviderTakesPrecedence": {
+			reason: "We should desire spec.forProvider.fields over the observed value of a field that is set in both.",
+			args: args{
+				forProvider:  `{"cool":true}`,
+				initProvider: `{"cool":false}`,
+				observed:     `{"cool":false}`,
+			},
+			want: want{
+				fields: raw(`{"cool":true}`),
+			},
+		},
+		"IgnoreInitProviderFields": {
+			reason: "We should desire the observed value of fields that are set only in spec.initProvider.fields.",
+			args: args{
+				forProvider:  `{"cool":true,"nested":{"a":1}}`,
+				initProvider: `{"replicas":3,"nested":{"b":2}}`,
+				observed:     `{"cool":false,"replicas":5,"nested":{"a":1,"b":7,"c":3}}`,
+			},
+			want: want{
+				fields: raw(`{"cool":true,"nested":{"a":1,"b":7},"replicas":5}`),
+			},
+		},
+		"InitProviderFieldRemoved": {
+			reason: "We should not desire fields set only in spec.initProvider.fields that were removed from the external resource.",
+			args: args{
+				forProvider:  `{"cool":true}`,
+				initProvider: `{"replicas":3}`,
+				observed:     `{"cool":true}`,
+			},
+			want: want{
+				fields: raw(`{"cool":true}`),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nop := &v1alpha1.NopResource{Spec: v1alpha1.NopResourceSpec{
+				ForProvider:  v1alpha1.NopResourceParameters{Fields: raw(tc.args.forProvider)},
+				InitProvider: v1alpha1.NopResourceInitParameters{Fields: raw(tc.args.initProvider)},
+			}}
+			got, err := desiredFields(nop, raw(tc.args.observed))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ndesiredFields(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.fields, got); diff != "" {
+				t.Errorf("\n%s\ndesiredFields(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
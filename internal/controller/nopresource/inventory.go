@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+)
+
+const (
+	errListInventory     = "cannot list NopExternalResources"
+	errFmtAmbiguousName  = "%d NopExternalResources have external name %q"
+	errImportFields      = "cannot import fields"
+	errUnmarshalImported = "cannot unmarshal imported fields"
+)
+
+// lookup returns the pretend external resource declared by the
+// NopExternalResource with the supplied external name. It returns false if no
+// NopExternalResource declares a pretend external resource with that name.
+func (e *external) lookup(ctx context.Context, name string) (externalResource, bool, error) {
+	if name == "" {
+		return externalResource{}, false, nil
+	}
+
+	// NopExternalResources are keyed by spec.externalName, so we need to list
+	// them all to find the one we want.
+	l := &v1alpha1.NopExternalResourceList{}
+	if err := e.kube.List(ctx, l); err != nil {
+		return externalResource{}, false, errors.Wrap(err, errListInventory)
+	}
+
+	found := make([]v1alpha1.NopExternalResource, 0, 1)
+	for _, ner := range l.Items {
+		if ner.Spec.ExternalName == name {
+			found = append(found, ner)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return externalResource{}, false, nil
+	case 1:
+	default:
+		return externalResource{}, false, errors.Errorf(errFmtAmbiguousName, len(found), name)
+	}
+
+	ner := found[0]
+	er := externalResource{
+		CreatedAt: ner.GetCreationTimestamp().Time,
+		Fields:    *ner.Spec.Fields.DeepCopy(),
+		Imported:  true,
+	}
+	if len(ner.Spec.ConnectionDetails) > 0 {
+		er.ConnectionDetails = make(map[string][]byte, len(ner.Spec.ConnectionDetails))
+		for k, v := range ner.Spec.ConnectionDetails {
+			er.ConnectionDetails[k] = []byte(v)
+		}
+	}
+	return er, true, nil
+}
+
+// importFields writes the fields of the supplied imported external resource to
+// the supplied NopResource's status.atProvider.fields.
+func importFields(nop *v1alpha1.NopResource, er externalResource) error {
+	if !er.Imported || len(er.Fields.Raw) == 0 {
+		return nil
+	}
+
+	fields := map[string]any{}
+	if err := json.Unmarshal(er.Fields.Raw, &fields); err != nil {
+		return errors.Wrap(err, errUnmarshalImported)
+	}
+
+	to, err := statusFields(nop)
+	if err != nil {
+		return err
+	}
+	content := to.UnstructuredContent()
+	for k, v := range fields {
+		content[k] = v
+	}
+	to.SetUnstructuredContent(content)
+
+	return setStatusFields(nop, to)
+}
+
+// importConnectionDetails adds the connection details of the supplied imported
+// external resource to the supplied connection details, unless they're
+// already set.
+func importConnectionDetails(cd managed.ConnectionDetails, er externalResource) {
+	for k, v := range er.ConnectionDetails {
+		if _, ok := cd[k]; !ok {
+			cd[k] = v
+		}
+	}
+}
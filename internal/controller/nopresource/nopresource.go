@@ -19,17 +19,24 @@ package nopresource
 
 import (
 	"context"
-	"sort"
+	"encoding/json"
 	"time"
 
 	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/crossplane-contrib/provider-nop/internal/features"
+	"github.com/crossplane-contrib/provider-nop/internal/store"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
@@ -41,14 +48,28 @@ import (
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1alpha1.NopResourceGroupKind)
 
-	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha1.NopResourceGroupVersionKind),
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), v1alpha1.StoreConfigGroupVersionKind, connection.WithStoreBuilder(store.Builder)))
+	}
+
+	opts := []managed.ReconcilerOption{
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithExternalConnecter(&connecter{}),
+		managed.WithExternalConnecter(&connecter{
+			kube:  mgr.GetClient(),
+			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{}),
+			cloud: newFakeCloud(),
+		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		managed.WithMetricRecorder(o.MetricOptions.MRMetrics),
-	)
+		managed.WithConnectionPublishers(cps...),
+	}
+	if o.Features.Enabled(feature.EnableBetaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.NopResourceGroupVersionKind), opts...)
 
 	if err := ctrl.NewWebhookManagedBy(mgr).
 		For(&v1alpha1.NopResource{}).
@@ -69,71 +90,394 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
-type connecter struct{}
+const (
+	errNotNopResource     = "managed resource is not a NopResource"
+	errTrackPCUsage       = "cannot track ProviderConfig usage"
+	errGetPC              = "cannot get ProviderConfig"
+	errInitProvider       = "cannot apply spec.initProvider.fields"
+	errLateInit           = "cannot late-initialize spec.forProvider.fields"
+	errExternalName       = "cannot render external name from spec.forProvider.externalNameFormat"
+	errEmptyName          = "spec.forProvider.externalNameFormat rendered an empty external name"
+	errCreateInterrupted  = "creation was interrupted"
+	errObserveInterrupted = "observation was interrupted"
+	errConditions         = "cannot set status conditions"
+	errTrackSpecChanges   = "cannot track spec changes"
+	errObserveFields      = "cannot set status.atProvider.fields"
+	errConnectionDetails  = "cannot get connection details"
+	errRecoverSecrets     = "cannot recover generated connection details"
+)
 
-func (c *connecter) Connect(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
-	return managed.ExternalClientFns{ObserveFn: Observe}, nil
+type connecter struct {
+	kube  client.Client
+	usage resource.Tracker
+	cloud *fakeCloud
 }
 
-// Observe doesn't actually observe an external resource. Instead it sets the
-// most recent conditions that should occur per spec.forProvider.conditionAfter.
-func Observe(_ context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	// If our managed resource has been deleted we need to report that
-	// our pretend external resource is gone in order for the delete
-	// process to complete. This means we'll never call the DeleteFn.
-	if meta.WasDeleted(mg) {
-		return managed.ExternalObservation{ResourceExists: false}, nil
+// Connect to the fake cloud, once the NopResource's ProviderConfig usage has
+// been tracked and the ProviderConfig's credentials have been validated.
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	if _, ok := mg.(*v1alpha1.NopResource); !ok {
+		return nil, errors.New(errNotNopResource)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
 	}
 
+	pc := &v1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	if err := c.authenticate(ctx, pc.Spec.Credentials); err != nil {
+		return nil, err
+	}
+
+	return &external{kube: c.kube, cloud: c.cloud, config: pc.Spec}, nil
+}
+
+// An external client manages pretend external resources in an in-memory fake
+// cloud.
+type external struct {
+	kube  client.Client
+	cloud *fakeCloud
+
+	// config is the spec of the ProviderConfig the NopResource uses.
+	config v1alpha1.ProviderConfigSpec
+}
+
+// Observe the pretend external resource, and set the most recent conditions
+// that should occur per spec.forProvider.conditionAfter.
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
 	nop, ok := mg.(*v1alpha1.NopResource)
 	if !ok {
-		return managed.ExternalObservation{}, errors.Errorf("managed resource was not a %T", &v1alpha1.NopResource{})
+		return managed.ExternalObservation{}, errors.New(errNotNopResource)
 	}
 
-	// Sort conditions, with those that should occur latest appearing first.
-	// We rely on the fact that the managed.Reconciler won't persist this sorted
-	// array because it occurred during the Observe function, and we didn't
-	// return ResourceLateInitialized: true.
-	sort.SliceStable(nop.Spec.ForProvider.ConditionAfter, func(i, j int) bool {
-		return nop.Spec.ForProvider.ConditionAfter[i].Time.Duration > nop.Spec.ForProvider.ConditionAfter[j].Time.Duration
-	})
+	if d := e.config.ObserveLatency; d != nil {
+		t := time.NewTimer(d.Duration)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return managed.ExternalObservation{}, errors.Wrap(ctx.Err(), errObserveInterrupted)
+		}
+	}
 
-	age := time.Since(nop.ObjectMeta.CreationTimestamp.Time)
-	set := map[xpv1.ConditionType]bool{}
-	for _, ca := range nop.Spec.ForProvider.ConditionAfter {
-		if ca.Time.Duration > age {
-			// This condition should not occur yet.
-			continue
+	if err := e.injectedError(nop, v1alpha1.ExternalOperationObserve); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	name := meta.GetExternalName(nop)
+	er, exists := e.cloud.Get(name)
+	if !exists && !meta.WasDeleted(nop) {
+		// The external resource may be declared by a NopExternalResource, in
+		// which case we import it into the fake cloud.
+		imported, ok, err := e.lookup(ctx, name)
+		if err != nil {
+			return managed.ExternalObservation{}, err
 		}
+		if ok && nop.Status.AtProvider.ObservedGeneration != 0 {
+			// We imported this external resource before the provider
+			// restarted, and may since have updated it.
+			if imported, err = e.rehydrate(ctx, nop, imported); err != nil {
+				return managed.ExternalObservation{}, err
+			}
+		}
+		er, exists = imported, ok
+	}
+	if !exists {
+		// The fake cloud doesn't survive a provider restart. If we know we
+		// successfully created this external resource before, and it wasn't
+		// since deleted, pretend it still exists as of when it was created.
+		created := meta.GetExternalCreateSucceeded(nop)
+		if created.IsZero() || (meta.WasDeleted(nop) && !deletionPending(nop)) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		var err error
+		if er, err = e.rehydrate(ctx, nop, externalResource{CreatedAt: created}); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+	e.cloud.Put(name, er)
 
-		if set[ca.ConditionType] {
-			// We already encountered and set a condition of this type.
-			continue
+	nop.Status.AtProvider.UpdateCount = er.Updates
+	nop.Status.AtProvider.LastUpdateTime = nil
+	if !er.UpdatedAt.IsZero() {
+		nop.Status.AtProvider.LastUpdateTime = &metav1.Time{Time: er.UpdatedAt}
+	}
+
+	// Late-initialize any fields that the pretend external resource has
+	// defaulted. The managed.Reconciler will persist our spec if we do.
+	fields, li, err := lateInitialize(nop.Spec.ForProvider.Fields, nop.Spec.ForProvider.LateInitialize)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errLateInit)
+	}
+	nop.Spec.ForProvider.Fields = fields
+
+	now := time.Now()
+	if err := trackSpecChanges(nop, now); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errTrackSpecChanges)
+	}
+	if err := importFields(nop, er); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errImportFields)
+	}
+	if err := observeFields(nop); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFields)
+	}
+	if err := setScheduledFields(nop, now); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveFields)
+	}
+	ca := nop.Spec.ForProvider.ConditionAfter
+	if len(ca) == 0 {
+		ca = e.config.ConditionAfter
+	}
+	setScheduledConditions(nop, ca, now)
+	setCycledConditions(nop, now)
+	if err := setFieldConditions(nop); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errConditions)
+	}
+	if err := e.waitForDependencies(ctx, nop); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// Our pretend external resource may still be being created.
+	if d := nop.Spec.ForProvider.CreateDuration; d != nil && now.Sub(er.CreatedAt) < d.Duration {
+		nop.SetConditions(xpv1.Creating())
+	}
+
+	// Our pretend external resource may take a while to be deleted.
+	if meta.WasDeleted(nop) {
+		nop.SetConditions(xpv1.Deleting())
+	}
+
+	// Emit any connection details we were asked to.
+	cd, changed, err := e.connectionDetails(ctx, nop, &er, now)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errConnectionDetails)
+	}
+	if changed {
+		e.cloud.Put(name, er)
+	}
+	importConnectionDetails(cd, er)
+	nop.Status.AtProvider.LastRotationTime = nil
+	if !er.RotatedAt.IsZero() {
+		nop.Status.AtProvider.LastRotationTime = &metav1.Time{Time: er.RotatedAt}
+	}
+
+	desired, err := desiredFields(nop, er.Fields)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errInitProvider)
+	}
+
+	var drift time.Duration
+	if nop.Spec.ForProvider.DriftAfter != nil {
+		drift = nop.Spec.ForProvider.DriftAfter.Duration
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        er.UpToDate(desired, drift),
+		ResourceLateInitialized: li,
+		ConnectionDetails:       cd,
+	}, nil
+}
+
+// Create a pretend external resource in the fake cloud.
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	nop, ok := mg.(*v1alpha1.NopResource)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotNopResource)
+	}
+
+	if err := e.injectedError(nop, v1alpha1.ExternalOperationCreate); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	// Our pretend external resource defaults any fields we're asked to
+	// late-initialize.
+	fields, _, err := lateInitialize(nop.Spec.ForProvider.Fields, nop.Spec.ForProvider.LateInitialize)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errLateInit)
+	}
+
+	// Our pretend external resource is created with any fields that should
+	// only be set at creation time.
+	fields, _, err = lateInitialize(fields, nop.Spec.InitProvider.Fields)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errInitProvider)
+	}
+
+	// Our pretend external resource may have a generated name.
+	if f := nop.Spec.ForProvider.ExternalNameFormat; f != nil {
+		name, err := render(*f, nop)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errExternalName)
+		}
+		if name == "" {
+			return managed.ExternalCreation{}, errors.New(errEmptyName)
 		}
+		meta.SetExternalName(nop, name)
+	}
 
-		// This is the latest condition of this type that should be set.
-		var r xpv1.ConditionReason
-		if ca.ConditionReason != nil {
-			r = *ca.ConditionReason
+	// Synchronous creates block until our pretend external resource has been
+	// created, or we're cancelled.
+	if d := nop.Spec.ForProvider.CreateDuration; d != nil && nop.Spec.ForProvider.CreateMode == v1alpha1.CreateModeSynchronous {
+		t := time.NewTimer(d.Duration)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return managed.ExternalCreation{}, errors.Wrap(ctx.Err(), errCreateInterrupted)
 		}
-		nop.SetConditions(xpv1.Condition{
-			Type:               ca.ConditionType,
-			Status:             ca.ConditionStatus,
-			Reason:             r,
-			LastTransitionTime: metav1.Now(),
-		})
+	}
 
-		set[ca.ConditionType] = true
+	e.cloud.Put(meta.GetExternalName(nop), externalResource{
+		CreatedAt: time.Now(),
+		Fields:    fields,
+	})
+	return managed.ExternalCreation{}, nil
+}
+
+// Update the pretend external resource in the fake cloud.
+func (e *external) Update(_ context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	nop, ok := mg.(*v1alpha1.NopResource)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotNopResource)
 	}
 
-	// Emit any connection details we were asked to.
-	cd := managed.ConnectionDetails{}
-	for _, nv := range nop.Spec.ForProvider.ConnectionDetails {
-		cd[nv.Name] = []byte(nv.Value)
+	if err := e.injectedError(nop, v1alpha1.ExternalOperationUpdate); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	name := meta.GetExternalName(nop)
+	er, _ := e.cloud.Get(name)
+	fields, err := desiredFields(nop, er.Fields)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errInitProvider)
+	}
+	er.UpdatedAt = time.Now()
+	er.Updates++
+	er.Fields = fields
+	e.cloud.Put(name, er)
+
+	// Report the update now, rather than at the next observation, so it's
+	// persisted along with the rest of this reconcile's status.
+	nop.Status.AtProvider.UpdateCount = er.Updates
+	nop.Status.AtProvider.LastUpdateTime = &metav1.Time{Time: er.UpdatedAt}
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete the pretend external resource from the fake cloud, once
+// spec.forProvider.deletionAfter has passed since the NopResource was deleted.
+func (e *external) Delete(_ context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	nop, ok := mg.(*v1alpha1.NopResource)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotNopResource)
+	}
+
+	if err := e.injectedError(nop, v1alpha1.ExternalOperationDelete); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	if deletionPending(nop) {
+		// Deletion is still in progress. The managed.Reconciler will call
+		// Delete again until our pretend external resource is gone.
+		return managed.ExternalDelete{}, nil
+	}
+
+	e.cloud.Delete(meta.GetExternalName(nop))
+	return managed.ExternalDelete{}, nil
+}
+
+// rehydrate returns the supplied pretend external resource as the supplied
+// NopResource observed it before the provider restarted. The external resource
+// is either one the NopResource created, or one it imported. What we know about
+// it is recovered from the NopResource's spec and status, and from the
+// connection secret it wrote.
+func (e *external) rehydrate(ctx context.Context, nop *v1alpha1.NopResource, er externalResource) (externalResource, error) {
+	er.Updates = nop.Status.AtProvider.UpdateCount
+
+	// An imported external resource keeps the fields of its
+	// NopExternalResource until the NopResource updates it.
+	if !er.Imported || er.Updates > 0 {
+		er.Fields = *nop.Spec.ForProvider.Fields.DeepCopy()
+	}
+	if t := nop.Status.AtProvider.LastUpdateTime; t != nil {
+		er.UpdatedAt = t.Time
+	}
+	if t := nop.Status.AtProvider.LastRotationTime; t != nil {
+		er.RotatedAt = t.Time
+	}
+
+	secrets, err := e.recoverSecrets(ctx, nop, er.CreatedAt)
+	if err != nil {
+		return externalResource{}, errors.Wrap(err, errRecoverSecrets)
 	}
+	er.Secrets = secrets
+
+	return er, nil
+}
+
+// deletionPending returns true if the supplied NopResource was deleted less
+// than spec.forProvider.deletionAfter ago, and thus its pretend external
+// resource should still exist.
+func deletionPending(nop *v1alpha1.NopResource) bool {
+	d := nop.Spec.ForProvider.DeletionAfter
+	if d == nil || nop.GetDeletionTimestamp() == nil {
+		return false
+	}
+	return time.Since(nop.GetDeletionTimestamp().Time) < d.Duration
+}
+
+// Disconnect does nothing. There's nothing to disconnect from.
+func (e *external) Disconnect(_ context.Context) error {
+	return nil
+}
 
-	// If our managed resource has not been deleted we report that our
-	// pretend external resource exists and is up-to-date. This means
-	// we'll never call the CreateFn or UpdateFn.
-	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: cd}, nil
+// lateInitialize returns the supplied fields, with any values in the supplied
+// defaults that were not already set merged in. It returns true if any values
+// were merged.
+func lateInitialize(fields, defaults runtime.RawExtension) (runtime.RawExtension, bool, error) {
+	if len(defaults.Raw) == 0 {
+		return *fields.DeepCopy(), false, nil
+	}
+
+	f := map[string]any{}
+	if len(fields.Raw) > 0 {
+		if err := json.Unmarshal(fields.Raw, &f); err != nil {
+			return runtime.RawExtension{}, false, errors.Wrap(err, "cannot unmarshal fields")
+		}
+	}
+	d := map[string]any{}
+	if err := json.Unmarshal(defaults.Raw, &d); err != nil {
+		return runtime.RawExtension{}, false, errors.Wrap(err, "cannot unmarshal late-initialized fields")
+	}
+
+	if !mergeMissing(f, d) {
+		return *fields.DeepCopy(), false, nil
+	}
+
+	raw, err := json.Marshal(f)
+	return runtime.RawExtension{Raw: raw}, true, errors.Wrap(err, "cannot marshal fields")
+}
+
+// mergeMissing merges any values in src that are missing from dst into dst,
+// recursing into objects. It returns true if dst was changed.
+func mergeMissing(dst, src map[string]any) bool {
+	changed := false
+	for k, sv := range src {
+		dv, ok := dst[k]
+		if !ok {
+			dst[k] = sv
+			changed = true
+			continue
+		}
+		dm, dok := dv.(map[string]any)
+		sm, sok := sv.(map[string]any)
+		if dok && sok && mergeMissing(dm, sm) {
+			changed = true
+		}
+	}
+	return changed
 }
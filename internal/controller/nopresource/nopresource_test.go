@@ -23,11 +23,19 @@ import (
 
 	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 )
 
@@ -210,10 +218,1405 @@ func TestReconcileLogic(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			_, _ = Observe(context.Background(), tc.mg)
+			e := &external{cloud: newFakeCloud()}
+			e.cloud.Put(meta.GetExternalName(tc.mg), externalResource{})
+			_, _ = e.Observe(context.Background(), tc.mg)
 			if diff := cmp.Diff(tc.want, tc.mg, test.EquateConditions()); diff != "" {
 				t.Errorf("Observe(...): -want, +got:\n%s\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
+
+func TestConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	nop := &v1alpha1.NopResource{Spec: v1alpha1.NopResourceSpec{ResourceSpec: xpv1.ResourceSpec{
+		ProviderConfigReference: &xpv1.Reference{Name: "default"},
+	}}}
+
+	type fields struct {
+		kube  client.Client
+		usage resource.Tracker
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		mg     resource.Managed
+		want   error
+	}{
+		"NotNopResource": {
+			reason: "We should return an error if the managed resource is not a NopResource.",
+			mg:     &fake.Managed{},
+			want:   errors.New(errNotNopResource),
+		},
+		"TrackError": {
+			reason: "We should return any error encountered tracking ProviderConfig usage.",
+			fields: fields{
+				usage: resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return errBoom }),
+			},
+			mg:   nop,
+			want: errors.Wrap(errBoom, errTrackPCUsage),
+		},
+		"ProviderConfigMissing": {
+			reason: "We should return an error if the referenced ProviderConfig does not exist.",
+			fields: fields{
+				kube:  &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				usage: resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return nil }),
+			},
+			mg:   nop,
+			want: errors.Wrap(errBoom, errGetPC),
+		},
+		"Success": {
+			reason: "We should connect once we've tracked usage of an existing ProviderConfig.",
+			fields: fields{
+				kube:  &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+				usage: resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return nil }),
+			},
+			mg:   nop,
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &connecter{kube: tc.fields.kube, usage: tc.fields.usage, cloud: newFakeCloud()}
+			_, err := c.Connect(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	now := time.Now()
+	created := now.Add(-1 * time.Minute)
+	updated := now.Add(-30 * time.Second)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errBoom := errors.New("boom")
+
+	inventory := func(ners ...v1alpha1.NopExternalResource) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			obj.(*v1alpha1.NopExternalResourceList).Items = ners
+			return nil
+		}
+	}
+	ner := func(name string) v1alpha1.NopExternalResource {
+		return v1alpha1.NopExternalResource{Spec: v1alpha1.NopExternalResourceSpec{
+			ExternalName:      name,
+			Fields:            runtime.RawExtension{Raw: []byte(`{"size":1}`)},
+			ConnectionDetails: map[string]string{"password": "secret"},
+		}}
+	}
+
+	type args struct {
+		ctx    context.Context
+		kube   client.Client
+		config v1alpha1.ProviderConfigSpec
+		cloud  *fakeCloud
+		mg     resource.Managed
+	}
+	type want struct {
+		o          managed.ExternalObservation
+		err        error
+		cloud      map[string]externalResource
+		conditions []xpv1.Condition
+		atProvider v1alpha1.NopResourceObservation
+		fields     runtime.RawExtension
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotNopResource": {
+			reason: "We should return an error if the managed resource is not a NopResource.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg:    nil,
+			},
+			want: want{
+				err:   errors.New(errNotNopResource),
+				cloud: map[string]externalResource{},
+			},
+		},
+		"DoesNotExist": {
+			reason: "We should report that the external resource does not exist if it is not in the fake cloud.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+				}},
+			},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: false},
+				cloud: map[string]externalResource{},
+			},
+		},
+		"ImportListError": {
+			reason: "We should return any error encountered listing NopExternalResources.",
+			args: args{
+				kube:  &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+				}},
+			},
+			want: want{
+				err:   errors.Wrap(errBoom, errListInventory),
+				cloud: map[string]externalResource{},
+			},
+		},
+		"ImportAmbiguous": {
+			reason: "We should return an error if more than one NopExternalResource has the NopResource's external name.",
+			args: args{
+				kube:  &test.MockClient{MockList: inventory(ner("cool"), ner("cool"))},
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+				}},
+			},
+			want: want{
+				err:   errors.Errorf(errFmtAmbiguousName, 2, "cool"),
+				cloud: map[string]externalResource{},
+			},
+		},
+		"Imported": {
+			reason: "We should import an external resource declared by a NopExternalResource, and observe its fields and connection details.",
+			args: args{
+				kube:  &test.MockClient{MockList: inventory(ner("lame"), ner("cool"))},
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+				}},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: managed.ConnectionDetails{"password": []byte("secret")},
+				},
+				cloud: map[string]externalResource{"cool": {
+					Fields:            runtime.RawExtension{Raw: []byte(`{"size":1}`)},
+					Imported:          true,
+					ConnectionDetails: map[string][]byte{"password": []byte("secret")},
+				}},
+				atProvider: v1alpha1.NopResourceObservation{
+					Fields: runtime.RawExtension{Raw: []byte(`{"size":1}`)},
+				},
+			},
+		},
+		"ImportedBeforeRestart": {
+			reason: "We should recover what we knew about an external resource we imported before the provider restarted, rather than importing it anew.",
+			args: args{
+				kube: &test.MockClient{
+					MockList: inventory(ner("cool")),
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						if key != (client.ObjectKey{Namespace: "default", Name: "cool"}) {
+							return errBoom
+						}
+						obj.(*corev1.Secret).Data = map[string][]byte{"token": []byte("recovered")}
+						return nil
+					},
+				},
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Generation:  1,
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							WriteConnectionSecretToReference: &xpv1.SecretReference{Namespace: "default", Name: "cool"},
+						},
+						ForProvider: v1alpha1.NopResourceParameters{
+							Fields: runtime.RawExtension{Raw: []byte(`{"size":2}`)},
+							ConnectionDetails: []v1alpha1.ResourceConnectionDetail{{
+								Name:      "token",
+								ValueFrom: &v1alpha1.ConnectionDetailSource{Generated: &v1alpha1.GeneratedConnectionDetail{}},
+							}},
+						},
+					},
+					Status: v1alpha1.NopResourceStatus{AtProvider: v1alpha1.NopResourceObservation{
+						ObservedGeneration: 1,
+						UpdateCount:        4,
+						LastUpdateTime:     &metav1.Time{Time: updated},
+						LastRotationTime:   &metav1.Time{Time: updated},
+					}},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"token": []byte("recovered"), "password": []byte("secret")},
+				},
+				cloud: map[string]externalResource{"cool": {
+					UpdatedAt:         updated,
+					Updates:           4,
+					Fields:            runtime.RawExtension{Raw: []byte(`{"size":2}`)},
+					RotatedAt:         updated,
+					Imported:          true,
+					ConnectionDetails: map[string][]byte{"password": []byte("secret")},
+					Secrets: map[string]secret{"token": {
+						Values:      map[string][]byte{"token": []byte("recovered")},
+						GeneratedAt: updated,
+					}},
+				}},
+				fields: runtime.RawExtension{Raw: []byte(`{"size":2}`)},
+				atProvider: v1alpha1.NopResourceObservation{
+					ObservedGeneration: 1,
+					UpdateCount:        4,
+					LastUpdateTime:     &metav1.Time{Time: updated},
+					LastRotationTime:   &metav1.Time{Time: updated},
+					Fields:             runtime.RawExtension{Raw: []byte(`{"size":2}`)},
+				},
+			},
+		},
+		"Exists": {
+			reason: "We should report that the external resource exists and is up to date if it is in the fake cloud.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {CreatedAt: created}}},
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							ConnectionDetails: []v1alpha1.ResourceConnectionDetail{{Name: "username", Value: "fakeuser"}},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"username": []byte("fakeuser")},
+				},
+				cloud: map[string]externalResource{"cool": {CreatedAt: created}},
+			},
+		},
+		"Updated": {
+			reason: "We should report how many times the external resource has been updated, and when.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {CreatedAt: created, UpdatedAt: updated, Updates: 2}}},
+				mg: &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+				}},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				cloud: map[string]externalResource{"cool": {CreatedAt: created, UpdatedAt: updated, Updates: 2}},
+				atProvider: v1alpha1.NopResourceObservation{
+					UpdateCount:    2,
+					LastUpdateTime: &metav1.Time{Time: updated},
+				},
+			},
+		},
+		"FieldsChanged": {
+			reason: "We should report that the external resource is not up to date if its fields differ from the desired fields.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {
+					CreatedAt: created,
+					Fields:    runtime.RawExtension{Raw: []byte(`{"cool":true,"size":1}`)},
+				}}},
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							Fields: runtime.RawExtension{Raw: []byte(`{"size":2,"cool":true}`)},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				cloud: map[string]externalResource{"cool": {
+					CreatedAt: created,
+					Fields:    runtime.RawExtension{Raw: []byte(`{"cool":true,"size":1}`)},
+				}},
+				fields: runtime.RawExtension{Raw: []byte(`{"size":2,"cool":true}`)},
+			},
+		},
+		"FieldsUnchanged": {
+			reason: "We should report that the external resource is up to date if its fields are semantically equal to the desired fields.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {
+					CreatedAt: created,
+					Fields:    runtime.RawExtension{Raw: []byte(`{"cool":true,"size":1}`)},
+				}}},
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							Fields: runtime.RawExtension{Raw: []byte(`{"size": 1, "cool": true}`)},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				cloud: map[string]externalResource{"cool": {
+					CreatedAt: created,
+					Fields:    runtime.RawExtension{Raw: []byte(`{"cool":true,"size":1}`)},
+				}},
+				fields: runtime.RawExtension{Raw: []byte(`{"size": 1, "cool": true}`)},
+			},
+		},
+		"LateInitialized": {
+			reason: "We should late-initialize any fields that are not already set.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {
+					CreatedAt: created,
+					Fields:    runtime.RawExtension{Raw: []byte(`{"cool":true,"obj":{"a":1,"b":2},"size":1}`)},
+				}}},
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							Fields:         runtime.RawExtension{Raw: []byte(`{"cool":true,"obj":{"a":1}}`)},
+							LateInitialize: runtime.RawExtension{Raw: []byte(`{"cool":false,"obj":{"b":2},"size":1}`)},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+					ConnectionDetails:       managed.ConnectionDetails{},
+				},
+				cloud: map[string]externalResource{"cool": {
+					CreatedAt: created,
+					Fields:    runtime.RawExtension{Raw: []byte(`{"cool":true,"obj":{"a":1,"b":2},"size":1}`)},
+				}},
+				fields: runtime.RawExtension{Raw: []byte(`{"cool":true,"obj":{"a":1,"b":2},"size":1}`)},
+			},
+		},
+		"AlreadyLateInitialized": {
+			reason: "We should not report that we late-initialized fields if they were all already set.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {
+					CreatedAt: created,
+					Fields:    runtime.RawExtension{Raw: []byte(`{"cool":true,"size":1}`)},
+				}}},
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							Fields:         runtime.RawExtension{Raw: []byte(`{"cool":true,"size":1}`)},
+							LateInitialize: runtime.RawExtension{Raw: []byte(`{"size":3}`)},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				cloud: map[string]externalResource{"cool": {
+					CreatedAt: created,
+					Fields:    runtime.RawExtension{Raw: []byte(`{"cool":true,"size":1}`)},
+				}},
+				fields: runtime.RawExtension{Raw: []byte(`{"cool":true,"size":1}`)},
+			},
+		},
+		"Drifted": {
+			reason: "We should report that the external resource is not up to date if driftAfter has passed since it was last updated.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {CreatedAt: created, UpdatedAt: updated, Updates: 1}}},
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							DriftAfter: &metav1.Duration{Duration: 20 * time.Second},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				cloud: map[string]externalResource{"cool": {CreatedAt: created, UpdatedAt: updated, Updates: 1}},
+				atProvider: v1alpha1.NopResourceObservation{
+					UpdateCount:    1,
+					LastUpdateTime: &metav1.Time{Time: updated},
+				},
+			},
+		},
+		"NotYetDrifted": {
+			reason: "We should report that the external resource is up to date if driftAfter has not passed since it was last updated.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {CreatedAt: created, UpdatedAt: updated, Updates: 1}}},
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							DriftAfter: &metav1.Duration{Duration: 45 * time.Second},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				cloud: map[string]externalResource{"cool": {CreatedAt: created, UpdatedAt: updated, Updates: 1}},
+				atProvider: v1alpha1.NopResourceObservation{
+					UpdateCount:    1,
+					LastUpdateTime: &metav1.Time{Time: updated},
+				},
+			},
+		},
+		"StillCreating": {
+			reason: "We should report that the external resource is creating if createDuration has not passed since it was created.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {CreatedAt: created}}},
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							CreateDuration: &metav1.Duration{Duration: 2 * time.Minute},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				cloud:      map[string]externalResource{"cool": {CreatedAt: created}},
+				conditions: []xpv1.Condition{xpv1.Creating()},
+			},
+		},
+		"FinishedCreating": {
+			reason: "We should not report that the external resource is creating if createDuration has passed since it was created.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {CreatedAt: created}}},
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							CreateDuration: &metav1.Duration{Duration: 30 * time.Second},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				cloud: map[string]externalResource{"cool": {CreatedAt: created}},
+			},
+		},
+		"Deleting": {
+			reason: "We should report that the external resource exists and is being deleted if it is still in the fake cloud.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {CreatedAt: created}}},
+				mg: &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{
+					Annotations:       map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					DeletionTimestamp: &metav1.Time{Time: now},
+				}},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				cloud:      map[string]externalResource{"cool": {CreatedAt: created}},
+				conditions: []xpv1.Condition{xpv1.Deleting()},
+			},
+		},
+		"ObserveInterrupted": {
+			reason: "We should return an error if we're cancelled while waiting for the ProviderConfig's observe latency.",
+			args: args{
+				ctx:    cancelled,
+				config: v1alpha1.ProviderConfigSpec{ObserveLatency: &metav1.Duration{Duration: time.Minute}},
+				cloud:  &fakeCloud{resources: map[string]externalResource{"cool": {CreatedAt: created}}},
+				mg: &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+				}},
+			},
+			want: want{
+				err:   errors.Wrap(context.Canceled, errObserveInterrupted),
+				cloud: map[string]externalResource{"cool": {CreatedAt: created}},
+			},
+		},
+		"DefaultConditions": {
+			reason: "We should set the ProviderConfig's default conditions if the NopResource doesn't specify any.",
+			args: args{
+				config: v1alpha1.ProviderConfigSpec{ConditionAfter: []v1alpha1.ResourceConditionAfter{
+					{ConditionType: xpv1.TypeReady, ConditionStatus: corev1.ConditionTrue},
+				}},
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {CreatedAt: created}}},
+				mg: &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{
+					Annotations:       map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					CreationTimestamp: metav1.NewTime(created),
+				}},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				cloud:      map[string]externalResource{"cool": {CreatedAt: created}},
+				conditions: []xpv1.Condition{{Type: xpv1.TypeReady, Status: corev1.ConditionTrue}},
+			},
+		},
+		"OwnConditions": {
+			reason: "We should not set the ProviderConfig's default conditions if the NopResource specifies its own.",
+			args: args{
+				config: v1alpha1.ProviderConfigSpec{ConditionAfter: []v1alpha1.ResourceConditionAfter{
+					{ConditionType: xpv1.TypeReady, ConditionStatus: corev1.ConditionTrue},
+				}},
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {CreatedAt: created}}},
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations:       map[string]string{meta.AnnotationKeyExternalName: "cool"},
+						CreationTimestamp: metav1.NewTime(created),
+					},
+					Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+						ConditionAfter: []v1alpha1.ResourceConditionAfter{
+							{ConditionType: xpv1.TypeReady, ConditionStatus: corev1.ConditionFalse},
+						},
+					}},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				cloud:      map[string]externalResource{"cool": {CreatedAt: created}},
+				conditions: []xpv1.Condition{{Type: xpv1.TypeReady, Status: corev1.ConditionFalse}},
+			},
+		},
+		"ExistedBeforeRestart": {
+			reason: "We should assume an external resource we know we successfully created still exists.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						meta.AnnotationKeyExternalName:            "cool",
+						meta.AnnotationKeyExternalCreateSucceeded: created.Format(time.RFC3339),
+					},
+				}},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				cloud: map[string]externalResource{"cool": {CreatedAt: created.Truncate(time.Second)}},
+			},
+		},
+		"UpdatedBeforeRestart": {
+			reason: "We should recover how many times an external resource we know we successfully created was updated.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName:            "cool",
+							meta.AnnotationKeyExternalCreateSucceeded: created.Format(time.RFC3339),
+						},
+					},
+					Status: v1alpha1.NopResourceStatus{AtProvider: v1alpha1.NopResourceObservation{
+						UpdateCount:    5,
+						LastUpdateTime: &metav1.Time{Time: updated},
+					}},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				cloud: map[string]externalResource{"cool": {CreatedAt: created.Truncate(time.Second), UpdatedAt: updated, Updates: 5}},
+				atProvider: v1alpha1.NopResourceObservation{
+					UpdateCount:    5,
+					LastUpdateTime: &metav1.Time{Time: updated},
+				},
+			},
+		},
+		"GeneratedBeforeRestart": {
+			reason: "We should recover connection details we generated from the connection secret, and when they were last rotated, rather than generating new ones.",
+			args: args{
+				kube: &test.MockClient{
+					MockList: test.NewMockListFn(nil),
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						if key != (client.ObjectKey{Namespace: "default", Name: "cool"}) {
+							return errBoom
+						}
+						obj.(*corev1.Secret).Data = map[string][]byte{"password": []byte("recovered")}
+						return nil
+					},
+				},
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName:            "cool",
+							meta.AnnotationKeyExternalCreateSucceeded: created.Format(time.RFC3339),
+						},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							WriteConnectionSecretToReference: &xpv1.SecretReference{Namespace: "default", Name: "cool"},
+						},
+						ForProvider: v1alpha1.NopResourceParameters{
+							ConnectionDetails: []v1alpha1.ResourceConnectionDetail{{
+								Name:      "password",
+								ValueFrom: &v1alpha1.ConnectionDetailSource{Generated: &v1alpha1.GeneratedConnectionDetail{}},
+							}},
+						},
+					},
+					Status: v1alpha1.NopResourceStatus{AtProvider: v1alpha1.NopResourceObservation{
+						LastRotationTime: &metav1.Time{Time: updated},
+					}},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"password": []byte("recovered")},
+				},
+				cloud: map[string]externalResource{"cool": {
+					CreatedAt: created.Truncate(time.Second),
+					RotatedAt: updated,
+					Secrets: map[string]secret{"password": {
+						Values:      map[string][]byte{"password": []byte("recovered")},
+						GeneratedAt: updated,
+					}},
+				}},
+				atProvider: v1alpha1.NopResourceObservation{
+					LastRotationTime: &metav1.Time{Time: updated},
+				},
+			},
+		},
+		"RecoverSecretsError": {
+			reason: "We should return any error encountered recovering connection details we generated.",
+			args: args{
+				kube:  &test.MockClient{MockList: test.NewMockListFn(nil), MockGet: test.NewMockGetFn(errBoom)},
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName:            "cool",
+							meta.AnnotationKeyExternalCreateSucceeded: created.Format(time.RFC3339),
+						},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							WriteConnectionSecretToReference: &xpv1.SecretReference{Namespace: "default", Name: "cool"},
+						},
+					},
+				},
+			},
+			want: want{
+				err:   errors.Wrap(errors.Wrap(errBoom, "cannot get connection secret"), errRecoverSecrets),
+				cloud: map[string]externalResource{},
+			},
+		},
+		"DeletedBeforeRestart": {
+			reason: "We should not assume an external resource exists if its managed resource was deleted.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						meta.AnnotationKeyExternalName:            "cool",
+						meta.AnnotationKeyExternalCreateSucceeded: created.Format(time.RFC3339),
+					},
+					DeletionTimestamp: &metav1.Time{Time: now},
+				}},
+			},
+			want: want{
+				o:     managed.ExternalObservation{ResourceExists: false},
+				cloud: map[string]externalResource{},
+			},
+		},
+		"DeletingBeforeRestart": {
+			reason: "We should assume an external resource still exists if its managed resource was deleted less than deletionAfter ago.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							meta.AnnotationKeyExternalName:            "cool",
+							meta.AnnotationKeyExternalCreateSucceeded: created.Format(time.RFC3339),
+						},
+						DeletionTimestamp: &metav1.Time{Time: now},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							DeletionAfter: &metav1.Duration{Duration: 1 * time.Minute},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+				cloud:      map[string]externalResource{"cool": {CreatedAt: created.Truncate(time.Second)}},
+				conditions: []xpv1.Condition{xpv1.Deleting()},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := tc.args.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			kube := tc.args.kube
+			if kube == nil {
+				kube = &test.MockClient{MockList: test.NewMockListFn(nil)}
+			}
+			e := &external{kube: kube, cloud: tc.args.cloud, config: tc.args.config}
+			o, err := e.Observe(ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cloud, tc.args.cloud.resources, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cloud, +got cloud:\n%s\n", tc.reason, diff)
+			}
+			if nop, ok := tc.args.mg.(*v1alpha1.NopResource); ok {
+				if diff := cmp.Diff(tc.want.conditions, nop.Status.Conditions, test.EquateConditions(), cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want conditions, +got conditions:\n%s\n", tc.reason, diff)
+				}
+				if diff := cmp.Diff(tc.want.fields, nop.Spec.ForProvider.Fields, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want spec.forProvider.fields, +got spec.forProvider.fields:\n%s\n", tc.reason, diff)
+				}
+				if diff := cmp.Diff(tc.want.atProvider, nop.Status.AtProvider); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want status.atProvider, +got status.atProvider:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	type args struct {
+		ctx   context.Context
+		cloud *fakeCloud
+		mg    resource.Managed
+	}
+	type want struct {
+		err          error
+		cloud        map[string]externalResource
+		externalName string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotNopResource": {
+			reason: "We should return an error if the managed resource is not a NopResource.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg:    nil,
+			},
+			want: want{
+				err:   errors.New(errNotNopResource),
+				cloud: map[string]externalResource{},
+			},
+		},
+		"Created": {
+			reason: "We should add the external resource and its fields to the fake cloud.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							Fields: runtime.RawExtension{Raw: []byte(`{"cool":true}`)},
+						},
+					},
+				},
+			},
+			want: want{
+				cloud:        map[string]externalResource{"cool": {Fields: runtime.RawExtension{Raw: []byte(`{"cool":true}`)}}},
+				externalName: "cool",
+			},
+		},
+		"CreatedWithDefaults": {
+			reason: "We should create the external resource with any fields we're asked to late-initialize.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							Fields:         runtime.RawExtension{Raw: []byte(`{"cool":true}`)},
+							LateInitialize: runtime.RawExtension{Raw: []byte(`{"cool":false,"size":1}`)},
+						},
+					},
+				},
+			},
+			want: want{
+				cloud:        map[string]externalResource{"cool": {Fields: runtime.RawExtension{Raw: []byte(`{"cool":true,"size":1}`)}}},
+				externalName: "cool",
+			},
+		},
+		"CreatedWithInitProvider": {
+			reason: "We should create the external resource with any fields that should only be set at creation time.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							Fields: runtime.RawExtension{Raw: []byte(`{"cool":true}`)},
+						},
+						InitProvider: v1alpha1.NopResourceInitParameters{
+							Fields: runtime.RawExtension{Raw: []byte(`{"cool":false,"replicas":3}`)},
+						},
+					},
+				},
+			},
+			want: want{
+				cloud:        map[string]externalResource{"cool": {Fields: runtime.RawExtension{Raw: []byte(`{"cool":true,"replicas":3}`)}}},
+				externalName: "cool",
+			},
+		},
+		"GeneratedExternalName": {
+			reason: "We should generate the external name of the external resource if asked to.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "cool",
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							ExternalNameFormat: ptr.To("nop-{{ .metadata.name }}"),
+						},
+					},
+				},
+			},
+			want: want{
+				cloud:        map[string]externalResource{"nop-cool": {}},
+				externalName: "nop-cool",
+			},
+		},
+		"EmptyExternalName": {
+			reason: "We should return an error if we generate an empty external name.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "cool",
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							ExternalNameFormat: ptr.To(""),
+						},
+					},
+				},
+			},
+			want: want{
+				err:          errors.New(errEmptyName),
+				cloud:        map[string]externalResource{},
+				externalName: "cool",
+			},
+		},
+		"CreatedSynchronously": {
+			reason: "We should add the external resource to the fake cloud once createDuration has passed if we're creating synchronously.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							CreateDuration: &metav1.Duration{Duration: 10 * time.Millisecond},
+							CreateMode:     v1alpha1.CreateModeSynchronous,
+						},
+					},
+				},
+			},
+			want: want{
+				cloud:        map[string]externalResource{"cool": {}},
+				externalName: "cool",
+			},
+		},
+		"SynchronousCreateInterrupted": {
+			reason: "We should return an error without adding the external resource to the fake cloud if a synchronous create is interrupted.",
+			args: args{
+				ctx:   cancelled,
+				cloud: newFakeCloud(),
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							CreateDuration: &metav1.Duration{Duration: 1 * time.Minute},
+							CreateMode:     v1alpha1.CreateModeSynchronous,
+						},
+					},
+				},
+			},
+			want: want{
+				err:          errors.Wrap(context.Canceled, errCreateInterrupted),
+				cloud:        map[string]externalResource{},
+				externalName: "cool",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := tc.args.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			e := &external{cloud: tc.args.cloud}
+			_, err := e.Create(ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cloud, tc.args.cloud.resources, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(externalResource{}, "CreatedAt")); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want cloud, +got cloud:\n%s\n", tc.reason, diff)
+			}
+			if tc.args.mg != nil {
+				if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.args.mg)); diff != "" {
+					t.Errorf("\n%s\ne.Create(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	created := time.Now().Add(-1 * time.Minute)
+
+	type args struct {
+		cloud *fakeCloud
+		mg    resource.Managed
+	}
+	type want struct {
+		err         error
+		cloud       map[string]externalResource
+		updateCount int64
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotNopResource": {
+			reason: "We should return an error if the managed resource is not a NopResource.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg:    nil,
+			},
+			want: want{
+				err:   errors.New(errNotNopResource),
+				cloud: map[string]externalResource{},
+			},
+		},
+		"Updated": {
+			reason: "We should update the fields of the external resource in the fake cloud.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {CreatedAt: created}}},
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							Fields: runtime.RawExtension{Raw: []byte(`{"cool":true}`)},
+						},
+					},
+				},
+			},
+			want: want{
+				cloud:       map[string]externalResource{"cool": {CreatedAt: created, Updates: 1, Fields: runtime.RawExtension{Raw: []byte(`{"cool":true}`)}}},
+				updateCount: 1,
+			},
+		},
+		"UpdatedWithInitProvider": {
+			reason: "We should not update fields that are only set at creation time.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {CreatedAt: created, Fields: runtime.RawExtension{Raw: []byte(`{"cool":false,"replicas":5}`)}}}},
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							Fields: runtime.RawExtension{Raw: []byte(`{"cool":true}`)},
+						},
+						InitProvider: v1alpha1.NopResourceInitParameters{
+							Fields: runtime.RawExtension{Raw: []byte(`{"replicas":3}`)},
+						},
+					},
+				},
+			},
+			want: want{
+				cloud:       map[string]externalResource{"cool": {CreatedAt: created, Updates: 1, Fields: runtime.RawExtension{Raw: []byte(`{"cool":true,"replicas":5}`)}}},
+				updateCount: 1,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{cloud: tc.args.cloud}
+			_, err := e.Update(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cloud, tc.args.cloud.resources, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(externalResource{}, "UpdatedAt")); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want cloud, +got cloud:\n%s\n", tc.reason, diff)
+			}
+			if nop, ok := tc.args.mg.(*v1alpha1.NopResource); ok {
+				if nop.Status.AtProvider.UpdateCount != tc.want.updateCount {
+					t.Errorf("\n%s\ne.Update(...): want status.atProvider.updateCount %d, got %d\n", tc.reason, tc.want.updateCount, nop.Status.AtProvider.UpdateCount)
+				}
+				if got := nop.Status.AtProvider.LastUpdateTime; got == nil || !got.Time.Equal(tc.args.cloud.resources["cool"].UpdatedAt) {
+					t.Errorf("\n%s\ne.Update(...): want status.atProvider.lastUpdateTime to be the time of the update, got %v\n", tc.reason, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type args struct {
+		cloud *fakeCloud
+		mg    resource.Managed
+	}
+	type want struct {
+		err   error
+		cloud map[string]externalResource
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotNopResource": {
+			reason: "We should return an error if the managed resource is not a NopResource.",
+			args: args{
+				cloud: newFakeCloud(),
+				mg:    nil,
+			},
+			want: want{
+				err:   errors.New(errNotNopResource),
+				cloud: map[string]externalResource{},
+			},
+		},
+		"DeletionInProgress": {
+			reason: "We should not remove the external resource from the fake cloud until deletionAfter has passed.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {}}},
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations:       map[string]string{meta.AnnotationKeyExternalName: "cool"},
+						DeletionTimestamp: &metav1.Time{Time: time.Now().Add(-10 * time.Second)},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							DeletionAfter: &metav1.Duration{Duration: 30 * time.Second},
+						},
+					},
+				},
+			},
+			want: want{
+				cloud: map[string]externalResource{"cool": {}},
+			},
+		},
+		"DeletionAfterPassed": {
+			reason: "We should remove the external resource from the fake cloud once deletionAfter has passed.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {}}},
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations:       map[string]string{meta.AnnotationKeyExternalName: "cool"},
+						DeletionTimestamp: &metav1.Time{Time: time.Now().Add(-1 * time.Minute)},
+					},
+					Spec: v1alpha1.NopResourceSpec{
+						ForProvider: v1alpha1.NopResourceParameters{
+							DeletionAfter: &metav1.Duration{Duration: 30 * time.Second},
+						},
+					},
+				},
+			},
+			want: want{
+				cloud: map[string]externalResource{},
+			},
+		},
+		"Deleted": {
+			reason: "We should remove the external resource from the fake cloud.",
+			args: args{
+				cloud: &fakeCloud{resources: map[string]externalResource{"cool": {}, "other": {}}},
+				mg: &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool"},
+				}},
+			},
+			want: want{
+				cloud: map[string]externalResource{"other": {}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{cloud: tc.args.cloud}
+			_, err := e.Delete(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cloud, tc.args.cloud.resources, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want cloud, +got cloud:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestInjectedError(t *testing.T) {
+	errBoom := errors.New("boom")
+	created := metav1.NewTime(time.Now().Add(-1 * time.Minute))
+
+	type args struct {
+		config v1alpha1.ProviderConfigSpec
+		mg     *v1alpha1.NopResource
+		op     v1alpha1.ExternalOperation
+		calls  int
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   error
+	}{
+		"NoErrors": {
+			reason: "We should not return an error if none are configured.",
+			args: args{
+				mg: &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created}},
+				op: v1alpha1.ExternalOperationObserve,
+			},
+			want: nil,
+		},
+		"OtherOperation": {
+			reason: "We should not return an error configured for a different operation.",
+			args: args{
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+					Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+						ErrorsAfter: []v1alpha1.ResourceErrorAfter{
+							{Operation: v1alpha1.ExternalOperationCreate, ErrorMessage: errBoom.Error()},
+						},
+					}},
+				},
+				op: v1alpha1.ExternalOperationObserve,
+			},
+			want: nil,
+		},
+		"NotYet": {
+			reason: "We should not return an error before its time has passed.",
+			args: args{
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+					Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+						ErrorsAfter: []v1alpha1.ResourceErrorAfter{
+							{Time: metav1.Duration{Duration: 2 * time.Minute}, Operation: v1alpha1.ExternalOperationObserve, ErrorMessage: errBoom.Error()},
+						},
+					}},
+				},
+				op: v1alpha1.ExternalOperationObserve,
+			},
+			want: nil,
+		},
+		"NoLonger": {
+			reason: "We should not return an error after its duration has passed.",
+			args: args{
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+					Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+						ErrorsAfter: []v1alpha1.ResourceErrorAfter{
+							{
+								Time:         metav1.Duration{Duration: 10 * time.Second},
+								Duration:     &metav1.Duration{Duration: 20 * time.Second},
+								Operation:    v1alpha1.ExternalOperationObserve,
+								ErrorMessage: errBoom.Error(),
+							},
+						},
+					}},
+				},
+				op: v1alpha1.ExternalOperationObserve,
+			},
+			want: nil,
+		},
+		"WithinWindow": {
+			reason: "We should return an error once its time has passed, until its duration has passed.",
+			args: args{
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+					Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+						ErrorsAfter: []v1alpha1.ResourceErrorAfter{
+							{
+								Time:         metav1.Duration{Duration: 30 * time.Second},
+								Duration:     &metav1.Duration{Duration: 1 * time.Minute},
+								Operation:    v1alpha1.ExternalOperationObserve,
+								ErrorMessage: errBoom.Error(),
+							},
+						},
+					}},
+				},
+				op: v1alpha1.ExternalOperationObserve,
+			},
+			want: errBoom,
+		},
+		"WithinCount": {
+			reason: "We should return an error until it has been returned count times.",
+			args: args{
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+					Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+						ErrorsAfter: []v1alpha1.ResourceErrorAfter{
+							{Count: ptr.To[int64](3), Operation: v1alpha1.ExternalOperationCreate, ErrorMessage: errBoom.Error()},
+						},
+					}},
+				},
+				op:    v1alpha1.ExternalOperationCreate,
+				calls: 2,
+			},
+			want: errBoom,
+		},
+		"Outage": {
+			reason: "We should return an error for every operation when the ProviderConfig reports an outage.",
+			args: args{
+				config: v1alpha1.ProviderConfigSpec{Outage: true},
+				mg:     &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created}},
+				op:     v1alpha1.ExternalOperationDelete,
+			},
+			want: errors.New(errOutage),
+		},
+		"ErrorRateAlways": {
+			reason: "We should return an error when the ProviderConfig's error rate is 100 percent.",
+			args: args{
+				config: v1alpha1.ProviderConfigSpec{ErrorRatePercent: ptr.To[int32](100)},
+				mg:     &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created}},
+				op:     v1alpha1.ExternalOperationObserve,
+			},
+			want: errors.New(errErrorRate),
+		},
+		"ErrorRateNever": {
+			reason: "We should not return an error when the ProviderConfig's error rate is 0 percent.",
+			args: args{
+				config: v1alpha1.ProviderConfigSpec{ErrorRatePercent: ptr.To[int32](0)},
+				mg:     &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created}},
+				op:     v1alpha1.ExternalOperationObserve,
+			},
+			want: nil,
+		},
+		"CountExhausted": {
+			reason: "We should not return an error once it has been returned count times.",
+			args: args{
+				mg: &v1alpha1.NopResource{
+					ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+					Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+						ErrorsAfter: []v1alpha1.ResourceErrorAfter{
+							{Count: ptr.To[int64](3), Operation: v1alpha1.ExternalOperationCreate, ErrorMessage: errBoom.Error()},
+						},
+					}},
+				},
+				op:    v1alpha1.ExternalOperationCreate,
+				calls: 3,
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{cloud: newFakeCloud(), config: tc.args.config}
+			for i := 0; i < tc.args.calls; i++ {
+				_ = e.injectedError(tc.args.mg, tc.args.op)
+			}
+			err := e.injectedError(tc.args.mg, tc.args.op)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.injectedError(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
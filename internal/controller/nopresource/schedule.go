@@ -0,0 +1,279 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// trackSpecChanges records when a change to the supplied NopResource's spec was
+// last observed. Spec changes increment a resource's generation, but so do
+// changes the provider makes to the spec, like late-initializing fields or
+// resolving references. We compare a hash of the spec the provider doesn't
+// write to tell the two apart.
+func trackSpecChanges(nop *v1alpha1.NopResource, now time.Time) error {
+	if nop.Status.AtProvider.ObservedGeneration == nop.GetGeneration() {
+		return nil
+	}
+
+	h, err := specHash(nop)
+	if err != nil {
+		return err
+	}
+
+	// A resource's first observed spec is its spec at creation time.
+	if prev := nop.Status.AtProvider.ObservedSpecHash; prev != "" && prev != h {
+		nop.Status.AtProvider.LastSpecChangeTime = &metav1.Time{Time: now}
+	}
+	nop.Status.AtProvider.ObservedGeneration = nop.GetGeneration()
+	nop.Status.AtProvider.ObservedSpecHash = h
+	return nil
+}
+
+// specHash returns a hash of the parts of the supplied NopResource's spec that
+// the provider doesn't write. Late-initialized fields are included, but they
+// are merged into spec.forProvider.fields before the spec is hashed whether
+// or not they've been persisted yet. The dependsOn field is written by the
+// reference resolver when dependsOnRefs or dependsOnSelector are set, and the
+// dependsOnRefs field is written when dependsOnSelector is set.
+func specHash(nop *v1alpha1.NopResource) (string, error) {
+	spec := nop.Spec.DeepCopy()
+	if len(spec.ForProvider.DependsOnRefs) > 0 || spec.ForProvider.DependsOnSelector != nil {
+		spec.ForProvider.DependsOn = nil
+	}
+	if spec.ForProvider.DependsOnSelector != nil {
+		spec.ForProvider.DependsOnRefs = nil
+	}
+
+	// Converting to unstructured ensures schemaless fields are hashed the same
+	// regardless of how their keys are ordered.
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(spec)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot convert spec to unstructured")
+	}
+	b, err := json.Marshal(u)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot marshal spec")
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write(b)
+	return strconv.FormatUint(h.Sum64(), 16), nil
+}
+
+// anchorTime returns the time at which the supplied anchor event occurred. It
+// returns false if the event has not occurred.
+func anchorTime(nop *v1alpha1.NopResource, a v1alpha1.ScheduleAnchor) (time.Time, bool) {
+	switch a {
+	case v1alpha1.ScheduleAnchorLastSpecChange:
+		if t := nop.Status.AtProvider.LastSpecChangeTime; t != nil {
+			return t.Time, true
+		}
+		return nop.GetCreationTimestamp().Time, true
+	case v1alpha1.ScheduleAnchorDeletion:
+		if t := nop.GetDeletionTimestamp(); t != nil {
+			return t.Time, true
+		}
+		return time.Time{}, false
+	case v1alpha1.ScheduleAnchorCreation:
+		return nop.GetCreationTimestamp().Time, true
+	}
+	return nop.GetCreationTimestamp().Time, true
+}
+
+// setScheduledConditions sets the most recent of the supplied conditions that
+// should occur. The conditions are typically spec.forProvider.conditionAfter.
+func setScheduledConditions(nop *v1alpha1.NopResource, conditions []v1alpha1.ResourceConditionAfter, now time.Time) {
+	type occurrence struct {
+		at time.Time
+		ca v1alpha1.ResourceConditionAfter
+	}
+
+	occurred := make([]occurrence, 0, len(conditions))
+	for i, ca := range conditions {
+		t, ok := anchorTime(nop, ca.Anchor)
+		if !ok {
+			// This condition's anchor event hasn't happened yet.
+			continue
+		}
+
+		after, ok := randomize(nop, fmt.Sprintf("conditionAfter/%d", i), ca)
+		if !ok {
+			// This condition should never occur.
+			continue
+		}
+
+		at := t.Add(after)
+		if at.After(now) {
+			// This condition should not occur yet.
+			continue
+		}
+
+		occurred = append(occurred, occurrence{at: at, ca: ca})
+	}
+
+	// Sort conditions, with those that occurred latest appearing first.
+	sort.SliceStable(occurred, func(i, j int) bool {
+		return occurred[i].at.After(occurred[j].at)
+	})
+
+	set := map[xpv1.ConditionType]bool{}
+	for _, o := range occurred {
+		if set[o.ca.ConditionType] {
+			// We already encountered and set a condition of this type.
+			continue
+		}
+
+		// This is the latest condition of this type that should be set.
+		setCondition(nop, o.ca)
+		set[o.ca.ConditionType] = true
+	}
+}
+
+// setCycledConditions sets the most recent conditions that should occur per
+// spec.forProvider.conditionCycle.
+func setCycledConditions(nop *v1alpha1.NopResource, now time.Time) {
+	cc := nop.Spec.ForProvider.ConditionCycle
+	if cc == nil || cc.Period.Duration <= 0 {
+		return
+	}
+
+	t, ok := anchorTime(nop, cc.Anchor)
+	if !ok {
+		// The first cycle's anchor event hasn't happened yet.
+		return
+	}
+
+	elapsed := now.Sub(t)
+	if elapsed < 0 {
+		return
+	}
+	pos := elapsed % cc.Period.Duration
+
+	// Sort conditions, with those that occur latest in the cycle appearing
+	// first. Conditions that would occur after the cycle repeats never occur,
+	// but those that are only pushed past the end of the cycle by jitter wrap
+	// around to its start.
+	conditions := make([]v1alpha1.ResourceConditionAfter, 0, len(cc.Conditions))
+	for i, ca := range cc.Conditions {
+		if ca.Time.Duration >= cc.Period.Duration {
+			continue
+		}
+		after, ok := randomize(nop, fmt.Sprintf("conditionCycle/%d", i), ca)
+		if !ok {
+			continue
+		}
+		ca.Time = metav1.Duration{Duration: after % cc.Period.Duration}
+		conditions = append(conditions, ca)
+	}
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return conditions[i].Time.Duration > conditions[j].Time.Duration
+	})
+
+	latest := map[xpv1.ConditionType]v1alpha1.ResourceConditionAfter{}
+
+	// Conditions that occurred latest in the previous cycle, if any...
+	if elapsed >= cc.Period.Duration {
+		for _, ca := range conditions {
+			if _, ok := latest[ca.ConditionType]; !ok {
+				latest[ca.ConditionType] = ca
+			}
+		}
+	}
+
+	// ...are superseded by those that occurred latest in this cycle.
+	current := map[xpv1.ConditionType]bool{}
+	for _, ca := range conditions {
+		if ca.Time.Duration > pos || current[ca.ConditionType] {
+			continue
+		}
+		latest[ca.ConditionType] = ca
+		current[ca.ConditionType] = true
+	}
+
+	// Set conditions in a stable order.
+	for _, ca := range cc.Conditions {
+		if l, ok := latest[ca.ConditionType]; ok {
+			setCondition(nop, l)
+			delete(latest, ca.ConditionType)
+		}
+	}
+}
+
+// randomize returns the time after which the supplied condition should occur,
+// including any jitter. It returns false if the condition should never occur
+// per its probability. Jitter and probability are derived from the supplied
+// NopResource's UID and key, so they're consistent across reconciles.
+func randomize(nop *v1alpha1.NopResource, key string, ca v1alpha1.ResourceConditionAfter) (time.Duration, bool) {
+	after := ca.Time.Duration
+	if ca.ProbabilityPercent != nil && random(nop, key+"/probability")*100 >= float64(*ca.ProbabilityPercent) {
+		return after, false
+	}
+	if ca.Jitter != nil {
+		after += time.Duration(random(nop, key+"/jitter") * float64(ca.Jitter.Duration))
+	}
+	return after, true
+}
+
+// random returns a pseudo-random number in [0.0, 1.0) derived from the supplied
+// NopResource's UID and key.
+func random(nop *v1alpha1.NopResource, key string) float64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(nop.GetUID()))
+	_, _ = h.Write([]byte(key))
+	return rand.New(rand.NewPCG(h.Sum64(), 0)).Float64() //nolint:gosec // These values needn't be cryptographically secure.
+}
+
+// setCondition sets the supplied condition. A message that can't be rendered
+// is replaced by the error that prevented it from being rendered, rather than
+// preventing the condition from being set.
+func setCondition(nop *v1alpha1.NopResource, ca v1alpha1.ResourceConditionAfter) {
+	var r xpv1.ConditionReason
+	if ca.ConditionReason != nil {
+		r = *ca.ConditionReason
+	}
+
+	var msg string
+	if ca.ConditionMessage != nil {
+		m, err := render(*ca.ConditionMessage, nop)
+		if err != nil {
+			m = errors.Wrap(err, "cannot render message").Error()
+		}
+		msg = m
+	}
+
+	nop.SetConditions(xpv1.Condition{
+		Type:               ca.ConditionType,
+		Status:             ca.ConditionStatus,
+		Reason:             r,
+		Message:            msg,
+		LastTransitionTime: metav1.Now(),
+	})
+}
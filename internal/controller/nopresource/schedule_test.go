@@ -0,0 +1,456 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestTrackSpecChanges(t *testing.T) {
+	now := time.Now()
+	earlier := metav1.NewTime(now.Add(-1 * time.Minute))
+
+	spec := func(fields string, dependsOn ...string) v1alpha1.NopResourceSpec {
+		return v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+			Fields:        runtime.RawExtension{Raw: []byte(fields)},
+			DependsOn:     dependsOn,
+			DependsOnRefs: []xpv1.Reference{{Name: "dependency"}},
+		}}
+	}
+	hash := func(s v1alpha1.NopResourceSpec) string {
+		h, _ := specHash(&v1alpha1.NopResource{Spec: s})
+		return h
+	}
+
+	cases := map[string]struct {
+		reason string
+		nop    *v1alpha1.NopResource
+		want   v1alpha1.NopResourceObservation
+	}{
+		"FirstObservation": {
+			reason: "We should not record a spec change the first time we observe a NopResource's spec.",
+			nop: &v1alpha1.NopResource{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Spec:       spec(`{"cool":true}`),
+			},
+			want: v1alpha1.NopResourceObservation{
+				ObservedGeneration: 1,
+				ObservedSpecHash:   hash(spec(`{"cool":true}`)),
+			},
+		},
+		"SpecChanged": {
+			reason: "We should record a spec change when a NopResource's generation and spec change.",
+			nop: &v1alpha1.NopResource{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       spec(`{"cool":false}`),
+				Status: v1alpha1.NopResourceStatus{AtProvider: v1alpha1.NopResourceObservation{
+					ObservedGeneration: 2,
+					ObservedSpecHash:   hash(spec(`{"cool":true}`)),
+					LastSpecChangeTime: &earlier,
+				}},
+			},
+			want: v1alpha1.NopResourceObservation{
+				ObservedGeneration: 3,
+				ObservedSpecHash:   hash(spec(`{"cool":false}`)),
+				LastSpecChangeTime: &metav1.Time{Time: now},
+			},
+		},
+		"FieldsReordered": {
+			reason: "We should not record a spec change when only the order of a NopResource's fields changes.",
+			nop: &v1alpha1.NopResource{
+				ObjectMeta: metav1.ObjectMeta{Generation: 3},
+				Spec:       spec(`{"b":2,"a":1}`),
+				Status: v1alpha1.NopResourceStatus{AtProvider: v1alpha1.NopResourceObservation{
+					ObservedGeneration: 2,
+					ObservedSpecHash:   hash(spec(`{"a":1,"b":2}`)),
+				}},
+			},
+			want: v1alpha1.NopResourceObservation{
+				ObservedGeneration: 3,
+				ObservedSpecHash:   hash(spec(`{"a":1,"b":2}`)),
+			},
+		},
+		"ReferencesResolved": {
+			reason: "We should not record a spec change when the provider resolves a NopResource's references.",
+			nop: &v1alpha1.NopResource{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       spec(`{"cool":true}`, "dependency"),
+				Status: v1alpha1.NopResourceStatus{AtProvider: v1alpha1.NopResourceObservation{
+					ObservedGeneration: 1,
+					ObservedSpecHash:   hash(spec(`{"cool":true}`)),
+				}},
+			},
+			want: v1alpha1.NopResourceObservation{
+				ObservedGeneration: 2,
+				ObservedSpecHash:   hash(spec(`{"cool":true}`)),
+			},
+		},
+		"SpecUnchanged": {
+			reason: "We should not record a spec change when a NopResource's generation hasn't changed.",
+			nop: &v1alpha1.NopResource{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status: v1alpha1.NopResourceStatus{AtProvider: v1alpha1.NopResourceObservation{
+					ObservedGeneration: 2,
+					LastSpecChangeTime: &earlier,
+				}},
+			},
+			want: v1alpha1.NopResourceObservation{
+				ObservedGeneration: 2,
+				LastSpecChangeTime: &earlier,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := trackSpecChanges(tc.nop, now)
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ntrackSpecChanges(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, tc.nop.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ntrackSpecChanges(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetScheduledConditions(t *testing.T) {
+	now := time.Now()
+	ready := func(s corev1.ConditionStatus, after time.Duration, a v1alpha1.ScheduleAnchor) v1alpha1.ResourceConditionAfter {
+		return v1alpha1.ResourceConditionAfter{
+			Time:            metav1.Duration{Duration: after},
+			Anchor:          a,
+			ConditionType:   xpv1.TypeReady,
+			ConditionStatus: s,
+		}
+	}
+
+	type want struct {
+		conditions []xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		reason string
+		nop    *v1alpha1.NopResource
+		want   want
+	}{
+		"AnchoredToLastSpecChange": {
+			reason: "Conditions anchored to the last spec change should be relative to when it happened.",
+			nop: &v1alpha1.NopResource{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-5 * time.Minute))},
+				Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+					ConditionAfter: []v1alpha1.ResourceConditionAfter{
+						ready(corev1.ConditionTrue, 30*time.Second, v1alpha1.ScheduleAnchorCreation),
+						ready(corev1.ConditionFalse, 0, v1alpha1.ScheduleAnchorLastSpecChange),
+						ready(corev1.ConditionTrue, 30*time.Second, v1alpha1.ScheduleAnchorLastSpecChange),
+					},
+				}},
+				Status: v1alpha1.NopResourceStatus{AtProvider: v1alpha1.NopResourceObservation{
+					LastSpecChangeTime: &metav1.Time{Time: now.Add(-10 * time.Second)},
+				}},
+			},
+			want: want{conditions: []xpv1.Condition{{Type: xpv1.TypeReady, Status: corev1.ConditionFalse}}},
+		},
+		"SpecNeverChanged": {
+			reason: "Conditions anchored to the last spec change should be relative to creation if the spec never changed.",
+			nop: &v1alpha1.NopResource{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-5 * time.Minute))},
+				Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+					ConditionAfter: []v1alpha1.ResourceConditionAfter{
+						ready(corev1.ConditionFalse, 0, v1alpha1.ScheduleAnchorLastSpecChange),
+						ready(corev1.ConditionTrue, 30*time.Second, v1alpha1.ScheduleAnchorLastSpecChange),
+					},
+				}},
+			},
+			want: want{conditions: []xpv1.Condition{{Type: xpv1.TypeReady, Status: corev1.ConditionTrue}}},
+		},
+		"NotDeleted": {
+			reason: "Conditions anchored to deletion should not be set if the NopResource has not been deleted.",
+			nop: &v1alpha1.NopResource{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-5 * time.Minute))},
+				Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+					ConditionAfter: []v1alpha1.ResourceConditionAfter{
+						ready(corev1.ConditionTrue, 30*time.Second, v1alpha1.ScheduleAnchorCreation),
+						ready(corev1.ConditionFalse, 0, v1alpha1.ScheduleAnchorDeletion),
+					},
+				}},
+			},
+			want: want{conditions: []xpv1.Condition{{Type: xpv1.TypeReady, Status: corev1.ConditionTrue}}},
+		},
+		"Deleted": {
+			reason: "Conditions anchored to deletion should be relative to when the NopResource was deleted.",
+			nop: &v1alpha1.NopResource{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.NewTime(now.Add(-5 * time.Minute)),
+					DeletionTimestamp: &metav1.Time{Time: now.Add(-10 * time.Second)},
+				},
+				Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+					ConditionAfter: []v1alpha1.ResourceConditionAfter{
+						ready(corev1.ConditionTrue, 30*time.Second, v1alpha1.ScheduleAnchorCreation),
+						ready(corev1.ConditionFalse, 5*time.Second, v1alpha1.ScheduleAnchorDeletion),
+						ready(corev1.ConditionUnknown, 30*time.Second, v1alpha1.ScheduleAnchorDeletion),
+					},
+				}},
+			},
+			want: want{conditions: []xpv1.Condition{{Type: xpv1.TypeReady, Status: corev1.ConditionFalse}}},
+		},
+		"Message": {
+			reason: "Condition messages should be rendered from their template.",
+			nop: &v1alpha1.NopResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "cool",
+					CreationTimestamp: metav1.NewTime(now.Add(-5 * time.Minute)),
+				},
+				Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+					ConditionAfter: []v1alpha1.ResourceConditionAfter{{
+						Time:             metav1.Duration{Duration: 30 * time.Second},
+						ConditionType:    xpv1.TypeReady,
+						ConditionStatus:  corev1.ConditionTrue,
+						ConditionMessage: ptr.To("{{ .metadata.name }} is serving {{ .spec.forProvider.fields.replicas }} replicas"),
+					}},
+					Fields: runtime.RawExtension{Raw: []byte(`{"replicas":3}`)},
+				}},
+			},
+			want: want{conditions: []xpv1.Condition{{
+				Type:    xpv1.TypeReady,
+				Status:  corev1.ConditionTrue,
+				Message: "cool is serving 3 replicas",
+			}}},
+		},
+		"InvalidMessage": {
+			reason: "A condition whose message can't be rendered should still be set, with the rendering error as its message.",
+			nop: &v1alpha1.NopResource{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-5 * time.Minute))},
+				Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+					ConditionAfter: []v1alpha1.ResourceConditionAfter{{
+						ConditionType:    xpv1.TypeReady,
+						ConditionStatus:  corev1.ConditionTrue,
+						ConditionMessage: ptr.To("{{ .nope }}"),
+					}},
+				}},
+			},
+			want: want{conditions: []xpv1.Condition{{
+				Type:    xpv1.TypeReady,
+				Status:  corev1.ConditionTrue,
+				Message: `cannot render message: cannot execute template: template: :1:3: executing "" at <.nope>: map has no entry for key "nope"`,
+			}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			setScheduledConditions(tc.nop, tc.nop.Spec.ForProvider.ConditionAfter, now)
+			if diff := cmp.Diff(tc.want.conditions, tc.nop.Status.Conditions, test.EquateConditions(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nsetScheduledConditions(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetCycledConditions(t *testing.T) {
+	now := time.Now()
+	step := func(ct xpv1.ConditionType, s corev1.ConditionStatus, after time.Duration) v1alpha1.ResourceConditionAfter {
+		return v1alpha1.ResourceConditionAfter{
+			Time:            metav1.Duration{Duration: after},
+			ConditionType:   ct,
+			ConditionStatus: s,
+		}
+	}
+
+	// Ready for 30 seconds, then not ready for 10 seconds. Green after 10
+	// seconds of each cycle.
+	cycle := &v1alpha1.ResourceConditionCycle{
+		Period: metav1.Duration{Duration: 40 * time.Second},
+		Conditions: []v1alpha1.ResourceConditionAfter{
+			step(xpv1.TypeReady, corev1.ConditionTrue, 0),
+			step(xpv1.TypeReady, corev1.ConditionFalse, 30*time.Second),
+			step("Green", corev1.ConditionTrue, 10*time.Second),
+			step("Never", corev1.ConditionTrue, 40*time.Second),
+		},
+	}
+	nop := func(age time.Duration) *v1alpha1.NopResource {
+		return &v1alpha1.NopResource{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Spec:       v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{ConditionCycle: cycle}},
+		}
+	}
+
+	// Ready for 30 seconds plus up to 20 seconds of jitter, then not ready.
+	// The jitter for 'cool-uid' pushes the second step 43.5 seconds into the
+	// cycle, past its end, so it wraps around to 3.5 seconds.
+	jittered := func(age time.Duration) *v1alpha1.NopResource {
+		unready := step(xpv1.TypeReady, corev1.ConditionFalse, 30*time.Second)
+		unready.Jitter = &metav1.Duration{Duration: 20 * time.Second}
+		return &v1alpha1.NopResource{
+			ObjectMeta: metav1.ObjectMeta{UID: "cool-uid", CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{ConditionCycle: &v1alpha1.ResourceConditionCycle{
+				Period:     metav1.Duration{Duration: 40 * time.Second},
+				Conditions: []v1alpha1.ResourceConditionAfter{step(xpv1.TypeReady, corev1.ConditionTrue, 0), unready},
+			}}},
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		nop    *v1alpha1.NopResource
+		want   []xpv1.Condition
+	}{
+		"NoCycle": {
+			reason: "No conditions should be set if no cycle is configured.",
+			nop:    &v1alpha1.NopResource{},
+			want:   nil,
+		},
+		"FirstCycleStart": {
+			reason: "Only conditions that have occurred in the first cycle should be set.",
+			nop:    nop(5 * time.Second),
+			want: []xpv1.Condition{
+				{Type: xpv1.TypeReady, Status: corev1.ConditionTrue},
+			},
+		},
+		"FirstCycleEnd": {
+			reason: "The latest conditions of each type that have occurred in the first cycle should be set.",
+			nop:    nop(35 * time.Second),
+			want: []xpv1.Condition{
+				{Type: xpv1.TypeReady, Status: corev1.ConditionFalse},
+				{Type: "Green", Status: corev1.ConditionTrue},
+			},
+		},
+		"LaterCycleStart": {
+			reason: "Conditions from the previous cycle should be set until they're superseded in the current cycle.",
+			nop:    nop(205 * time.Second),
+			want: []xpv1.Condition{
+				{Type: xpv1.TypeReady, Status: corev1.ConditionTrue},
+				{Type: "Green", Status: corev1.ConditionTrue},
+			},
+		},
+		"JitteredPastPeriodBefore": {
+			reason: "A condition that jitter pushes past the end of the cycle should not be set before its wrapped time.",
+			nop:    jittered(2 * time.Second),
+			want: []xpv1.Condition{
+				{Type: xpv1.TypeReady, Status: corev1.ConditionTrue},
+			},
+		},
+		"JitteredPastPeriodAfter": {
+			reason: "A condition that jitter pushes past the end of the cycle should wrap around to the start of the cycle, not be dropped.",
+			nop:    jittered(39 * time.Second),
+			want: []xpv1.Condition{
+				{Type: xpv1.TypeReady, Status: corev1.ConditionFalse},
+			},
+		},
+		"LaterCycleEnd": {
+			reason: "The latest conditions of each type that have occurred in the current cycle should be set.",
+			nop:    nop(235 * time.Second),
+			want: []xpv1.Condition{
+				{Type: xpv1.TypeReady, Status: corev1.ConditionFalse},
+				{Type: "Green", Status: corev1.ConditionTrue},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			setCycledConditions(tc.nop, now)
+			if diff := cmp.Diff(tc.want, tc.nop.Status.Conditions, test.EquateConditions(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nsetCycledConditions(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRandomize(t *testing.T) {
+	nop := func(uid string) *v1alpha1.NopResource {
+		return &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{UID: types.UID(uid)}}
+	}
+	ca := v1alpha1.ResourceConditionAfter{Time: metav1.Duration{Duration: 30 * time.Second}}
+
+	t.Run("NoJitterOrProbability", func(t *testing.T) {
+		after, ok := randomize(nop("a"), "key", ca)
+		if !ok || after != ca.Time.Duration {
+			t.Errorf("randomize(...): want %s, true, got %s, %t", ca.Time.Duration, after, ok)
+		}
+	})
+
+	t.Run("Jitter", func(t *testing.T) {
+		ca := ca
+		ca.Jitter = &metav1.Duration{Duration: 10 * time.Second}
+
+		distinct := map[time.Duration]bool{}
+		for i := 0; i < 100; i++ {
+			uid := fmt.Sprintf("uid-%d", i)
+			after, ok := randomize(nop(uid), "key", ca)
+			if !ok {
+				t.Fatalf("randomize(...): condition with jitter and no probability should always occur")
+			}
+			if after < ca.Time.Duration || after >= ca.Time.Duration+ca.Jitter.Duration {
+				t.Errorf("randomize(...): want time in [30s, 40s), got %s", after)
+			}
+			if again, _ := randomize(nop(uid), "key", ca); again != after {
+				t.Errorf("randomize(...): want the same time for the same UID, got %s and %s", after, again)
+			}
+			distinct[after] = true
+		}
+		if len(distinct) < 90 {
+			t.Errorf("randomize(...): want times to differ between UIDs, got %d distinct times for 100 UIDs", len(distinct))
+		}
+	})
+
+	t.Run("Probability", func(t *testing.T) {
+		cases := map[string]struct {
+			percent  int32
+			min, max int
+		}{
+			"Never":     {percent: 0, min: 0, max: 0},
+			"Sometimes": {percent: 50, min: 30, max: 70},
+			"Always":    {percent: 100, min: 100, max: 100},
+		}
+		for name, tc := range cases {
+			t.Run(name, func(t *testing.T) {
+				ca := ca
+				ca.ProbabilityPercent = ptr.To(tc.percent)
+
+				occurred := 0
+				for i := 0; i < 100; i++ {
+					uid := fmt.Sprintf("uid-%d", i)
+					_, ok := randomize(nop(uid), "key", ca)
+					if _, again := randomize(nop(uid), "key", ca); again != ok {
+						t.Errorf("randomize(...): want the same outcome for the same UID")
+					}
+					if ok {
+						occurred++
+					}
+				}
+				if occurred < tc.min || occurred > tc.max {
+					t.Errorf("randomize(...): want between %d and %d of 100 UIDs to occur, got %d", tc.min, tc.max, occurred)
+				}
+			})
+		}
+	})
+}
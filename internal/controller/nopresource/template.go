@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"math/rand/v2"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	alnum   = "abcdefghijklmnopqrstuvwxyz0123456789"
+	numeric = "0123456789"
+	hex     = "0123456789abcdef"
+
+	// maxRandLength is the longest random string templates may generate.
+	maxRandLength = 1024
+)
+
+// funcs that may be used in templates. They're loosely modelled on those of
+// https://masterminds.github.io/sprig/.
+var funcs = template.FuncMap{
+	"randAlnum":   func(n int) (string, error) { return randString(alnum, n) },
+	"randNumeric": func(n int) (string, error) { return randString(numeric, n) },
+	"randHex":     func(n int) (string, error) { return randString(hex, n) },
+	"uuid":        func() string { return string(uuid.NewUUID()) },
+	"lower":       strings.ToLower,
+	"upper":       strings.ToUpper,
+}
+
+func randString(charset string, n int) (string, error) {
+	if n < 0 || n > maxRandLength {
+		return "", errors.Errorf("cannot generate a random string of length %d: length must be between 0 and %d", n, maxRandLength)
+	}
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = charset[rand.IntN(len(charset))] //nolint:gosec // These values needn't be cryptographically secure.
+	}
+	return string(b), nil
+}
+
+// render the supplied Go template. The template is executed against the
+// supplied NopResource, so for example {{ .metadata.name }} renders its name.
+func render(tmpl string, nop *v1alpha1.NopResource) (string, error) {
+	age := func() string {
+		return time.Since(nop.GetCreationTimestamp().Time).Round(time.Second).String()
+	}
+
+	t, err := template.New("").Funcs(funcs).Funcs(template.FuncMap{"age": age}).Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot parse template")
+	}
+
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(nop)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot convert NopResource to unstructured")
+	}
+
+	b := &strings.Builder{}
+	if err := t.Execute(b, data); err != nil {
+		return "", errors.Wrap(err, "cannot execute template")
+	}
+	return b.String(), nil
+}
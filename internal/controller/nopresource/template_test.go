@@ -0,0 +1,112 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRender(t *testing.T) {
+	nop := &v1alpha1.NopResource{ObjectMeta: metav1.ObjectMeta{
+		Name:              "cool",
+		CreationTimestamp: metav1.NewTime(time.Now().Add(-90 * time.Second)),
+	}}
+
+	type want struct {
+		match *regexp.Regexp
+		err   bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		tmpl   string
+		want   want
+	}{
+		"Literal": {
+			reason: "A template with no actions should render as-is.",
+			tmpl:   "cool",
+			want:   want{match: regexp.MustCompile(`^cool$`)},
+		},
+		"ObjectField": {
+			reason: "A template should be able to access fields of the NopResource.",
+			tmpl:   "{{ .metadata.name }}-nop",
+			want:   want{match: regexp.MustCompile(`^cool-nop$`)},
+		},
+		"RandomSuffix": {
+			reason: "A template should be able to generate random strings.",
+			tmpl:   "{{ .metadata.name }}-{{ randAlnum 6 }}",
+			want:   want{match: regexp.MustCompile(`^cool-[a-z0-9]{6}$`)},
+		},
+		"FakeARN": {
+			reason: "A template should be able to generate realistic looking identifiers.",
+			tmpl:   "arn:aws:nop:us-east-1:{{ randNumeric 12 }}:nopresource/{{ .metadata.name | upper }}",
+			want:   want{match: regexp.MustCompile(`^arn:aws:nop:us-east-1:[0-9]{12}:nopresource/COOL$`)},
+		},
+		"FakeID": {
+			reason: "A template should be able to generate realistic looking identifiers.",
+			tmpl:   "nop-{{ randHex 17 }}",
+			want:   want{match: regexp.MustCompile(`^nop-[0-9a-f]{17}$`)},
+		},
+		"RandomTooLong": {
+			reason: "A template that generates a random string longer than the maximum should return an error.",
+			tmpl:   "{{ randAlnum 1000000000 }}",
+			want:   want{err: true},
+		},
+		"RandomNegative": {
+			reason: "A template that generates a random string of negative length should return an error.",
+			tmpl:   "{{ randHex -1 }}",
+			want:   want{err: true},
+		},
+		"UUID": {
+			reason: "A template should be able to generate a UUID.",
+			tmpl:   "{{ uuid }}",
+			want:   want{match: regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)},
+		},
+		"Age": {
+			reason: "A template should be able to render the age of the NopResource.",
+			tmpl:   "{{ .metadata.name }} is {{ age }} old",
+			want:   want{match: regexp.MustCompile(`^cool is 1m3[0-9]s old$`)},
+		},
+		"MissingKey": {
+			reason: "A template that accesses a field that doesn't exist should return an error.",
+			tmpl:   "{{ .metadata.nope }}",
+			want:   want{err: true},
+		},
+		"InvalidTemplate": {
+			reason: "An invalid template should return an error.",
+			tmpl:   "{{ .metadata.name ",
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := render(tc.tmpl, nop)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\nrender(...): want error %t, got error: %v", tc.reason, tc.want.err, err)
+			}
+			if tc.want.match != nil && !tc.want.match.MatchString(got) {
+				t.Errorf("\n%s\nrender(...): want match for %q, got %q", tc.reason, tc.want.match, got)
+			}
+		})
+	}
+}
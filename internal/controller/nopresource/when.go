@@ -0,0 +1,136 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"cmp"
+	"encoding/json"
+	"reflect"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+)
+
+// setFieldConditions sets the conditions whose fields match per
+// spec.forProvider.conditionWhen.
+func setFieldConditions(nop *v1alpha1.NopResource) error {
+	if len(nop.Spec.ForProvider.ConditionWhen) == 0 {
+		return nil
+	}
+
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(nop)
+	if err != nil {
+		return errors.Wrap(err, "cannot convert NopResource to unstructured")
+	}
+	p := fieldpath.Pave(data)
+
+	for _, cw := range nop.Spec.ForProvider.ConditionWhen {
+		ok, err := matches(p, cw)
+		if err != nil {
+			return errors.Wrapf(err, "cannot compare field %s", cw.FieldPath)
+		}
+		if !ok {
+			continue
+		}
+		setCondition(nop, v1alpha1.ResourceConditionAfter{
+			ConditionType:    cw.ConditionType,
+			ConditionStatus:  cw.ConditionStatus,
+			ConditionReason:  cw.ConditionReason,
+			ConditionMessage: cw.ConditionMessage,
+		})
+	}
+	return nil
+}
+
+// matches returns true if the supplied condition's field matches its value.
+func matches(p *fieldpath.Paved, cw v1alpha1.ResourceConditionWhen) (bool, error) {
+	v, err := p.GetValue(cw.FieldPath)
+	if fieldpath.IsNotFound(err) {
+		return cw.Operator == v1alpha1.FieldOperatorDoesNotExist, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Round-trip the field through JSON so that, for example, integers and
+	// floats compare as numbers.
+	got, err := normalize(v)
+	if err != nil {
+		return false, err
+	}
+	var want any
+	if cw.Value != nil {
+		want = parse(*cw.Value)
+	}
+
+	switch cw.Operator {
+	case v1alpha1.FieldOperatorExists:
+		return true, nil
+	case v1alpha1.FieldOperatorDoesNotExist:
+		return false, nil
+	case v1alpha1.FieldOperatorNotEqual:
+		return !reflect.DeepEqual(got, want), nil
+	case v1alpha1.FieldOperatorGreaterThan:
+		c, err := compareNumbers(got, want)
+		return c > 0, err
+	case v1alpha1.FieldOperatorGreaterThanOrEqual:
+		c, err := compareNumbers(got, want)
+		return c >= 0, err
+	case v1alpha1.FieldOperatorLessThan:
+		c, err := compareNumbers(got, want)
+		return c < 0, err
+	case v1alpha1.FieldOperatorLessThanOrEqual:
+		c, err := compareNumbers(got, want)
+		return c <= 0, err
+	case v1alpha1.FieldOperatorEqual:
+		return reflect.DeepEqual(got, want), nil
+	}
+	return reflect.DeepEqual(got, want), nil
+}
+
+// compareNumbers returns -1 if a is less than b, 0 if they're equal, and +1 if
+// a is greater than b. It returns an error if either isn't a number.
+func compareNumbers(a, b any) (int, error) {
+	af, aok := a.(float64)
+	bf, bok := b.(float64)
+	if !aok || !bok {
+		return 0, errors.Errorf("cannot compare %v to %v: only numbers can be compared", a, b)
+	}
+	return cmp.Compare(af, bf), nil
+}
+
+func normalize(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal field")
+	}
+	var out any
+	err = json.Unmarshal(b, &out)
+	return out, errors.Wrap(err, "cannot unmarshal field")
+}
+
+// parse the supplied value as JSON, or as a string if it isn't valid JSON.
+func parse(v string) any {
+	var out any
+	if err := json.Unmarshal([]byte(v), &out); err != nil {
+		return v
+	}
+	return out
+}
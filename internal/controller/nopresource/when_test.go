@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nopresource
+
+import (
+	"testing"
+
+	"github.com/crossplane-contrib/provider-nop/apis/v1alpha1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestSetFieldConditions(t *testing.T) {
+	nop := func(fields string, cw ...v1alpha1.ResourceConditionWhen) *v1alpha1.NopResource {
+		return &v1alpha1.NopResource{Spec: v1alpha1.NopResourceSpec{ForProvider: v1alpha1.NopResourceParameters{
+			Fields:        runtime.RawExtension{Raw: []byte(fields)},
+			ConditionWhen: cw,
+		}}}
+	}
+
+	ready := func(fieldPath string, op v1alpha1.FieldOperator, value *string) v1alpha1.ResourceConditionWhen {
+		return v1alpha1.ResourceConditionWhen{
+			FieldPath:       fieldPath,
+			Operator:        op,
+			Value:           value,
+			ConditionType:   xpv1.TypeReady,
+			ConditionStatus: corev1.ConditionTrue,
+		}
+	}
+
+	type want struct {
+		conditions []xpv1.Condition
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason string
+		nop    *v1alpha1.NopResource
+		want   want
+	}{
+		"Equal": {
+			reason: "We should set a condition when its field equals its value.",
+			nop:    nop(`{"replicas":3}`, ready("spec.forProvider.fields.replicas", v1alpha1.FieldOperatorEqual, ptr.To("3"))),
+			want:   want{conditions: []xpv1.Condition{{Type: xpv1.TypeReady, Status: corev1.ConditionTrue}}},
+		},
+		"EqualString": {
+			reason: "We should compare values that aren't valid JSON as strings.",
+			nop:    nop(`{"phase":"Running"}`, ready("spec.forProvider.fields.phase", v1alpha1.FieldOperatorEqual, ptr.To("Running"))),
+			want:   want{conditions: []xpv1.Condition{{Type: xpv1.TypeReady, Status: corev1.ConditionTrue}}},
+		},
+		"NotEqual": {
+			reason: "We should not set a condition when its field doesn't equal its value.",
+			nop:    nop(`{"replicas":2}`, ready("spec.forProvider.fields.replicas", v1alpha1.FieldOperatorEqual, ptr.To("3"))),
+			want:   want{},
+		},
+		"GreaterThanOrEqual": {
+			reason: "We should set a condition when its field is greater than or equal to its value.",
+			nop:    nop(`{"replicas":4}`, ready("spec.forProvider.fields.replicas", v1alpha1.FieldOperatorGreaterThanOrEqual, ptr.To("3"))),
+			want:   want{conditions: []xpv1.Condition{{Type: xpv1.TypeReady, Status: corev1.ConditionTrue}}},
+		},
+		"LessThan": {
+			reason: "We should not set a condition when its field is not less than its value.",
+			nop:    nop(`{"replicas":4}`, ready("spec.forProvider.fields.replicas", v1alpha1.FieldOperatorLessThan, ptr.To("3"))),
+			want:   want{},
+		},
+		"MissingField": {
+			reason: "We should not set a condition when its field doesn't exist.",
+			nop:    nop(`{}`, ready("spec.forProvider.fields.replicas", v1alpha1.FieldOperatorNotEqual, ptr.To("3"))),
+			want:   want{},
+		},
+		"DoesNotExist": {
+			reason: "We should set a DoesNotExist condition when its field doesn't exist.",
+			nop:    nop(`{}`, ready("spec.forProvider.fields.replicas", v1alpha1.FieldOperatorDoesNotExist, nil)),
+			want:   want{conditions: []xpv1.Condition{{Type: xpv1.TypeReady, Status: corev1.ConditionTrue}}},
+		},
+		"Exists": {
+			reason: "We should set an Exists condition when its field exists.",
+			nop:    nop(`{"replicas":0}`, ready("spec.forProvider.fields.replicas", v1alpha1.FieldOperatorExists, nil)),
+			want:   want{conditions: []xpv1.Condition{{Type: xpv1.TypeReady, Status: corev1.ConditionTrue}}},
+		},
+		"CompareNonNumber": {
+			reason: "We should return an error when asked to order a field that isn't a number.",
+			nop:    nop(`{"phase":"Running"}`, ready("spec.forProvider.fields.phase", v1alpha1.FieldOperatorGreaterThan, ptr.To("3"))),
+			want: want{err: errors.Wrap(
+				errors.New("cannot compare Running to 3: only numbers can be compared"),
+				"cannot compare field spec.forProvider.fields.phase",
+			)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := setFieldConditions(tc.nop)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nsetFieldConditions(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			// Ignore transition times, which we can't predict.
+			for i := range tc.nop.Status.Conditions {
+				tc.nop.Status.Conditions[i].LastTransitionTime = metav1.Time{}
+			}
+			if diff := cmp.Diff(tc.want.conditions, tc.nop.Status.Conditions, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nsetFieldConditions(...): -want conditions, +got conditions:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package store implements a file-backed external secret store.
+package store
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/connection/store"
+)
+
+const (
+	// FileScheme is the scheme of plugin endpoints that are served by a
+	// FileStore.
+	FileScheme = "file://"
+)
+
+const (
+	errInvalidName = "secret name and scope must be valid file names"
+	errReadSecret  = "cannot read secret file"
+	errParseSecret = "cannot parse secret file"
+	errWriteSecret = "cannot write secret file"
+	errDelSecret   = "cannot delete secret file"
+)
+
+// Builder builds a connection store per the supplied config. Plugin stores
+// whose endpoint is a file:// URL are built as a FileStore, which stands in for
+// a real secret store plugin. All other stores are built by crossplane-runtime.
+func Builder(ctx context.Context, local client.Client, tcfg *tls.Config, cfg xpv1.SecretStoreConfig) (connection.Store, error) {
+	if cfg.Type != nil && *cfg.Type == xpv1.SecretStorePlugin && cfg.Plugin != nil && strings.HasPrefix(cfg.Plugin.Endpoint, FileScheme) {
+		return NewFileStore(strings.TrimPrefix(cfg.Plugin.Endpoint, FileScheme), cfg.DefaultScope), nil
+	}
+	return connection.RuntimeStoreBuilder(ctx, local, tcfg, cfg)
+}
+
+// A FileStore stores secrets as JSON files on the local filesystem. Each
+// secret is stored at <dir>/<scope>/<name>.json. It exists to exercise
+// External Secret Store support without running a real secret store.
+type FileStore struct {
+	dir          string
+	defaultScope string
+}
+
+// NewFileStore returns a FileStore that stores secrets in the supplied
+// directory. Secrets without a scope use the supplied default scope.
+func NewFileStore(dir, defaultScope string) *FileStore {
+	return &FileStore{dir: dir, defaultScope: defaultScope}
+}
+
+// ReadKeyValues reads the named secret. The supplied secret is left empty if
+// the named secret does not exist.
+func (fs *FileStore) ReadKeyValues(_ context.Context, n store.ScopedName, s *store.Secret) error {
+	cur, err := fs.read(n)
+	if err != nil {
+		return err
+	}
+	s.ScopedName = n
+	if cur != nil {
+		s.Metadata = cur.Metadata
+		s.Data = cur.Data
+	}
+	return nil
+}
+
+// WriteKeyValues writes the supplied secret. The supplied write options are
+// called only if the secret already exists. It returns true if the secret was
+// changed.
+func (fs *FileStore) WriteKeyValues(ctx context.Context, s *store.Secret, wo ...store.WriteOption) (bool, error) {
+	cur, err := fs.read(s.ScopedName)
+	if err != nil {
+		return false, err
+	}
+
+	desired := &store.Secret{ScopedName: s.ScopedName, Metadata: s.Metadata, Data: s.Data}
+	if cur != nil {
+		for _, o := range wo {
+			if err := o(ctx, cur, desired); err != nil {
+				return false, err
+			}
+		}
+		if reflect.DeepEqual(cur.Data, desired.Data) {
+			// The write would be a no-op.
+			return false, nil
+		}
+	}
+
+	return true, fs.write(desired)
+}
+
+// DeleteKeyValues deletes the supplied keys from the supplied secret. The
+// secret is deleted if no keys are supplied, or no keys remain.
+func (fs *FileStore) DeleteKeyValues(ctx context.Context, s *store.Secret, do ...store.DeleteOption) error {
+	cur, err := fs.read(s.ScopedName)
+	if err != nil || cur == nil {
+		return err
+	}
+
+	for _, o := range do {
+		if err := o(ctx, s); err != nil {
+			return err
+		}
+	}
+
+	for k := range s.Data {
+		delete(cur.Data, k)
+	}
+	if len(s.Data) == 0 || len(cur.Data) == 0 {
+		p, err := fs.path(s.ScopedName)
+		if err != nil {
+			return err
+		}
+		return errors.Wrap(os.Remove(p), errDelSecret)
+	}
+	return fs.write(cur)
+}
+
+func (fs *FileStore) path(n store.ScopedName) (string, error) {
+	scope := n.Scope
+	if scope == "" {
+		scope = fs.defaultScope
+	}
+	for _, s := range []string{scope, n.Name} {
+		if s == "" || s != filepath.Base(s) || s == "." || s == ".." {
+			return "", errors.New(errInvalidName)
+		}
+	}
+	return filepath.Join(fs.dir, scope, n.Name+".json"), nil
+}
+
+// read the named secret. It returns nil if the secret does not exist.
+func (fs *FileStore) read(n store.ScopedName) (*store.Secret, error) {
+	p, err := fs.path(n)
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(p) //nolint:gosec // The path is sanitized above.
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errReadSecret)
+	}
+	s := &store.Secret{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, errors.Wrap(err, errParseSecret)
+	}
+	return s, nil
+}
+
+// write the supplied secret. The secret file is replaced atomically, so a
+// concurrent read never observes a partially written secret.
+func (fs *FileStore) write(s *store.Secret) error {
+	p, err := fs.path(s.ScopedName)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return errors.Wrap(err, errWriteSecret)
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return errors.Wrap(err, errWriteSecret)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p), filepath.Base(p)+".*")
+	if err != nil {
+		return errors.Wrap(err, errWriteSecret)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // The file won't exist if it was renamed.
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, errWriteSecret)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, errWriteSecret)
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), p), errWriteSecret)
+}
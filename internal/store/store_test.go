@@ -0,0 +1,384 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/connection/store"
+	"github.com/crossplane/crossplane-runtime/pkg/connection/store/kubernetes"
+	"github.com/crossplane/crossplane-runtime/pkg/connection/store/plugin"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+const defaultScope = "crossplane-system"
+
+// newFileStore returns a FileStore in a temporary directory, containing the
+// supplied secrets.
+func newFileStore(t *testing.T, existing ...*store.Secret) *FileStore {
+	t.Helper()
+	fs := NewFileStore(t.TempDir(), defaultScope)
+	for _, s := range existing {
+		if err := fs.write(s); err != nil {
+			t.Fatalf("fs.write(...): %s", err)
+		}
+	}
+	return fs
+}
+
+func TestBuilder(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cfg    xpv1.SecretStoreConfig
+		want   connection.Store
+		err    error
+	}{
+		"FilePlugin": {
+			reason: "We should build a FileStore for a plugin store with a file:// endpoint.",
+			cfg: xpv1.SecretStoreConfig{
+				Type:         ptr.To(xpv1.SecretStorePlugin),
+				DefaultScope: defaultScope,
+				Plugin:       &xpv1.PluginStoreConfig{Endpoint: "file:///tmp/secrets"},
+			},
+			want: NewFileStore("/tmp/secrets", defaultScope),
+		},
+		"Plugin": {
+			reason: "We should let crossplane-runtime build a plugin store with any other endpoint.",
+			cfg: xpv1.SecretStoreConfig{
+				Type:         ptr.To(xpv1.SecretStorePlugin),
+				DefaultScope: defaultScope,
+				Plugin:       &xpv1.PluginStoreConfig{Endpoint: "ess-plugin-vault.crossplane-system:4040"},
+			},
+			want: &plugin.SecretStore{},
+		},
+		"Kubernetes": {
+			reason: "We should let crossplane-runtime build a Kubernetes store.",
+			cfg: xpv1.SecretStoreConfig{
+				Type:         ptr.To(xpv1.SecretStoreKubernetes),
+				DefaultScope: defaultScope,
+			},
+			want: &kubernetes.SecretStore{},
+		},
+		"UnknownType": {
+			reason: "We should return any error encountered by crossplane-runtime.",
+			cfg: xpv1.SecretStoreConfig{
+				Type: ptr.To(xpv1.SecretStoreType("Cool")),
+			},
+			// crossplane-runtime's errors are created by fmt.Errorf.
+			err: fmt.Errorf("unknown secret store type: %q", "Cool"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Builder(context.Background(), &test.MockClient{}, nil, tc.cfg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nBuilder(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(FileStore{}), cmpopts.IgnoreUnexported(plugin.SecretStore{}, kubernetes.SecretStore{})); diff != "" {
+				t.Errorf("\n%s\nBuilder(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReadKeyValues(t *testing.T) {
+	n := store.ScopedName{Name: "cool-secret"}
+	md := &xpv1.ConnectionSecretMetadata{Labels: map[string]string{"cool": "very"}}
+
+	type want struct {
+		s   *store.Secret
+		err error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		existing []*store.Secret
+		n        store.ScopedName
+		want     want
+	}{
+		"DoesNotExist": {
+			reason: "We should return an empty secret if the secret does not exist.",
+			n:      n,
+			want:   want{s: &store.Secret{ScopedName: n}},
+		},
+		"Exists": {
+			reason:   "We should return the secret's metadata and binary data if the secret exists.",
+			existing: []*store.Secret{{ScopedName: n, Metadata: md, Data: store.KeyValues{"a": []byte("1"), "b": {0x00, 0xff}}}},
+			n:        n,
+			want:     want{s: &store.Secret{ScopedName: n, Metadata: md, Data: store.KeyValues{"a": []byte("1"), "b": {0x00, 0xff}}}},
+		},
+		"InvalidName": {
+			reason: "We should return an error if the secret name isn't a valid file name.",
+			n:      store.ScopedName{Name: "../escape"},
+			want:   want{s: &store.Secret{}, err: errors.New(errInvalidName)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := newFileStore(t, tc.existing...)
+			s := &store.Secret{}
+			err := fs.ReadKeyValues(context.Background(), tc.n, s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nfs.ReadKeyValues(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.s, s); diff != "" {
+				t.Errorf("\n%s\nfs.ReadKeyValues(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWriteKeyValues(t *testing.T) {
+	errBoom := errors.New("boom")
+	n := store.ScopedName{Name: "cool-secret"}
+	md := &xpv1.ConnectionSecretMetadata{Labels: map[string]string{"cool": "very"}}
+
+	type args struct {
+		s  *store.Secret
+		wo []store.WriteOption
+	}
+	type want struct {
+		changed bool
+		err     error
+		s       *store.Secret
+	}
+
+	cases := map[string]struct {
+		reason   string
+		existing []*store.Secret
+		args     args
+		want     want
+	}{
+		"Created": {
+			reason: "We should create a secret that does not exist.",
+			args: args{
+				s: &store.Secret{ScopedName: n, Metadata: md, Data: store.KeyValues{"a": []byte("1")}},
+			},
+			want: want{
+				changed: true,
+				s:       &store.Secret{ScopedName: n, Metadata: md, Data: store.KeyValues{"a": []byte("1")}},
+			},
+		},
+		"Updated": {
+			reason:   "We should update a secret whose data differs.",
+			existing: []*store.Secret{{ScopedName: n, Data: store.KeyValues{"a": []byte("1")}}},
+			args: args{
+				s: &store.Secret{ScopedName: n, Data: store.KeyValues{"a": []byte("2")}},
+			},
+			want: want{
+				changed: true,
+				s:       &store.Secret{ScopedName: n, Data: store.KeyValues{"a": []byte("2")}},
+			},
+		},
+		"Unchanged": {
+			reason:   "We should not report a change if the secret's data is identical.",
+			existing: []*store.Secret{{ScopedName: n, Data: store.KeyValues{"a": []byte("1")}}},
+			args: args{
+				s: &store.Secret{ScopedName: n, Data: store.KeyValues{"a": []byte("1")}},
+			},
+			want: want{
+				changed: false,
+				s:       &store.Secret{ScopedName: n, Data: store.KeyValues{"a": []byte("1")}},
+			},
+		},
+		"WriteOptionError": {
+			reason:   "We should apply write options to existing secrets, and return any error they return.",
+			existing: []*store.Secret{{ScopedName: n, Data: store.KeyValues{"a": []byte("1")}}},
+			args: args{
+				s:  &store.Secret{ScopedName: n, Data: store.KeyValues{"a": []byte("2")}},
+				wo: []store.WriteOption{func(_ context.Context, _, _ *store.Secret) error { return errBoom }},
+			},
+			want: want{
+				err: errBoom,
+				s:   &store.Secret{ScopedName: n, Data: store.KeyValues{"a": []byte("1")}},
+			},
+		},
+		"InvalidName": {
+			reason: "We should return an error if the secret name isn't a valid file name.",
+			args: args{
+				s: &store.Secret{ScopedName: store.ScopedName{Name: "../escape"}},
+			},
+			want: want{
+				err: errors.New(errInvalidName),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := newFileStore(t, tc.existing...)
+			changed, err := fs.WriteKeyValues(context.Background(), tc.args.s, tc.args.wo...)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nfs.WriteKeyValues(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if changed != tc.want.changed {
+				t.Errorf("\n%s\nfs.WriteKeyValues(...): want changed %t, got %t\n", tc.reason, tc.want.changed, changed)
+			}
+			if tc.want.s == nil {
+				return
+			}
+			got, _ := fs.read(tc.args.s.ScopedName)
+			if diff := cmp.Diff(tc.want.s, got); diff != "" {
+				t.Errorf("\n%s\nfs.WriteKeyValues(...): -want secret, +got secret:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDeleteKeyValues(t *testing.T) {
+	errBoom := errors.New("boom")
+	n := store.ScopedName{Name: "cool-secret"}
+	existing := &store.Secret{ScopedName: n, Data: store.KeyValues{"a": []byte("1"), "b": []byte("2")}}
+
+	type args struct {
+		s  *store.Secret
+		do []store.DeleteOption
+	}
+	type want struct {
+		err error
+		s   *store.Secret
+	}
+
+	cases := map[string]struct {
+		reason   string
+		existing []*store.Secret
+		args     args
+		want     want
+	}{
+		"DoesNotExist": {
+			reason: "We should return early if the secret does not exist.",
+			args: args{
+				s:  &store.Secret{ScopedName: n},
+				do: []store.DeleteOption{func(_ context.Context, _ *store.Secret) error { return errBoom }},
+			},
+			want: want{s: nil},
+		},
+		"DeleteKeys": {
+			reason:   "We should delete only the supplied keys.",
+			existing: []*store.Secret{existing},
+			args: args{
+				s: &store.Secret{ScopedName: n, Data: store.KeyValues{"a": nil}},
+			},
+			want: want{s: &store.Secret{ScopedName: n, Data: store.KeyValues{"b": []byte("2")}}},
+		},
+		"DeleteAllKeys": {
+			reason:   "We should delete the secret if no keys remain.",
+			existing: []*store.Secret{existing},
+			args: args{
+				s: &store.Secret{ScopedName: n, Data: store.KeyValues{"a": nil, "b": nil}},
+			},
+			want: want{s: nil},
+		},
+		"DeleteSecret": {
+			reason:   "We should delete the secret if no keys are supplied.",
+			existing: []*store.Secret{existing},
+			args: args{
+				s: &store.Secret{ScopedName: n},
+			},
+			want: want{s: nil},
+		},
+		"DeleteOptionError": {
+			reason:   "We should return any error returned by a delete option.",
+			existing: []*store.Secret{existing},
+			args: args{
+				s:  &store.Secret{ScopedName: n},
+				do: []store.DeleteOption{func(_ context.Context, _ *store.Secret) error { return errBoom }},
+			},
+			want: want{err: errBoom, s: existing},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := newFileStore(t, tc.existing...)
+			err := fs.DeleteKeyValues(context.Background(), tc.args.s, tc.args.do...)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nfs.DeleteKeyValues(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			got, _ := fs.read(tc.args.s.ScopedName)
+			if diff := cmp.Diff(tc.want.s, got); diff != "" {
+				t.Errorf("\n%s\nfs.DeleteKeyValues(...): -want secret, +got secret:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	dir := "/tmp/secrets"
+
+	type want struct {
+		path string
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		n      store.ScopedName
+		want   want
+	}{
+		"Scoped": {
+			reason: "We should store a scoped secret in its scope's directory.",
+			n:      store.ScopedName{Scope: "cool-scope", Name: "cool-secret"},
+			want:   want{path: filepath.Join(dir, "cool-scope", "cool-secret.json")},
+		},
+		"DefaultScope": {
+			reason: "We should store a secret without a scope in the default scope's directory.",
+			n:      store.ScopedName{Name: "cool-secret"},
+			want:   want{path: filepath.Join(dir, defaultScope, "cool-secret.json")},
+		},
+		"EmptyName": {
+			reason: "We should return an error if the secret has no name.",
+			n:      store.ScopedName{},
+			want:   want{err: errors.New(errInvalidName)},
+		},
+		"NameTraversal": {
+			reason: "We should return an error if the secret name would escape the store's directory.",
+			n:      store.ScopedName{Name: "../escape"},
+			want:   want{err: errors.New(errInvalidName)},
+		},
+		"ScopeTraversal": {
+			reason: "We should return an error if the secret scope would escape the store's directory.",
+			n:      store.ScopedName{Scope: "..", Name: "cool-secret"},
+			want:   want{err: errors.New(errInvalidName)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fs := NewFileStore(dir, defaultScope)
+			got, err := fs.path(tc.n)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nfs.path(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.path, got); diff != "" {
+				t.Errorf("\n%s\nfs.path(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}